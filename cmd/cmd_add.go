@@ -2,12 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 )
 
 // AddContext handles the add command, validating and adding contexts with optional sub-cluster scanning.
 // It manages program output for the operation.
-func AddContext(name, server, token string, scan *string) error {
+func AddContext(name, server, token string, scan *string, opts ScanOptions) error {
 	const op = "kubeconfig.AddContext"
 
 	// Validating input parameters
@@ -27,42 +28,99 @@ func AddContext(name, server, token string, scan *string) error {
 	}
 
 	// Adding the primary context to the list
-	var contexts []ContextConfig
-	contexts = append(contexts, ContextConfig{
+	primary := ContextConfig{
 		Name:   name,
 		Server: server,
 		Token:  token,
-	})
+	}
+	if scan != nil {
+		primary.Metadata.Scan = NewScanRecord(*scan, opts)
+	}
+	var contexts []ContextConfig
+	contexts = append(contexts, primary)
 
 	// Scanning for sub-clusters if requested
+	var scanResults []ScanResult
+	var scanFailures []ScanFailure
 	if scan != nil {
-		scannedContexts, err := Scan(name, server, token, *scan)
-		if err != nil {
-			return fmt.Errorf("%s: failed to scan sub-clusters for type %q: %w", op, *scan, err)
+		result := ScanContext(primary, *scan, opts)
+		for _, f := range result.AllFailures() {
+			fmt.Printf("\033[31m[%s] Failed to scan sub-clusters for %s with type %q: %v\033[0m\n", op, f.Context, f.Type, f.Err)
 		}
-		if len(scannedContexts) == 0 {
-			fmt.Printf("\033[33m[%s] No sub-clusters found for type %q\033[0m\n", op, *scan)
-		} else {
-			contexts = append(contexts, scannedContexts...)
+		if len(result.Types) == 0 {
+			fmt.Printf("\033[33m[%s] No known platform detected for %s\033[0m\n", op, name)
+		} else if len(result.Contexts) == 0 && len(result.Failures) == 0 {
+			fmt.Printf("\033[33m[%s] No sub-clusters found for type %q\033[0m\n", op, strings.Join(result.Types, ","))
 		}
+		subContexts := result.AllContexts()
+		if opts.Interactive {
+			selected, err := SelectContexts(os.Stdin, subContexts)
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			subContexts = selected
+		}
+		contexts = append(contexts, subContexts...)
+		scanFailures = append(scanFailures, result.AllFailures()...)
+		scanResults = append(scanResults, result)
 	}
 
 	// Adding all contexts
 	fmt.Printf("\033[36m[%s] Adding contexts...\033[0m\n", op)
-	successCount := 0
+	var addedContexts []ContextConfig
+	refreshedCount := 0
+	skippedExistingCount := 0
 	for _, ctx := range contexts {
-		if err := NewContext(ctx.Name, ctx.Server, ctx.Token); err != nil {
+		if refreshed, err := RefreshContext(ctx); err != nil {
+			fmt.Printf("\033[31m  ✗ Failed to refresh context %s: %v\033[0m\n", ctx.Name, err)
+			continue
+		} else if refreshed {
+			fmt.Printf("\033[32m  ↻ Refreshed context: %s (%s)\033[0m\n", ctx.Name, ctx.Server)
+			refreshedCount++
+			continue
+		}
+
+		// Keeping contexts added by an earlier run of the same scan
+		config, _, err := GetKubeConfig()
+		if err != nil {
+			fmt.Printf("\033[31m  ✗ Failed to add context %s: %v\033[0m\n", ctx.Name, err)
+			continue
+		}
+		if rescannedContext(config, ctx) {
+			fmt.Printf("\033[33m  - Skipped context: %s (already exists)\033[0m\n", ctx.Name)
+			skippedExistingCount++
+			continue
+		}
+		if err := NewContext(ctx); err != nil {
 			fmt.Printf("\033[31m  ✗ Failed to add context %s: %v\033[0m\n", ctx.Name, err)
 			continue
 		}
 		fmt.Printf("\033[32m  ✓ Added context: %s (%s)\033[0m\n", ctx.Name, ctx.Server)
-		successCount++
+		if ctx.Warning != "" {
+			fmt.Printf("\033[33m    ⚠ %s\033[0m\n", ctx.Warning)
+		}
+		addedContexts = append(addedContexts, ctx)
 	}
 
 	// Displaying summary
 	fmt.Printf("\033[36m\n%s Summary:\n", op)
-	fmt.Printf("  ✓ Added contexts: %d\n", successCount)
-	fmt.Printf("  ✗ Failed contexts: %d\n", len(contexts)-successCount)
+	fmt.Printf("  ✓ Added contexts: %d\n", len(addedContexts))
+	if refreshedCount > 0 {
+		fmt.Printf("  ↻ Refreshed contexts: %d\n", refreshedCount)
+	}
+	if skippedExistingCount > 0 {
+		fmt.Printf("  - Skipped existing contexts: %d\n", skippedExistingCount)
+	}
+	fmt.Printf("  ✗ Failed contexts: %d\n", len(contexts)-len(addedContexts)-refreshedCount-skippedExistingCount)
+	printContextWarnings(addedContexts)
+	if scan != nil && opts.Depth > 1 {
+		printScanTree(scanResults)
+	} else if scan != nil && *scan == AutoScan {
+		printScanTypes(scanResults)
+	}
+	if len(scanFailures) > 0 {
+		printScanFailures(scanFailures)
+	}
 	fmt.Println(strings.Repeat("=", 50) + "\033[0m")
 
 	return nil
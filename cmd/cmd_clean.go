@@ -32,7 +32,7 @@ func CleanContextCmd() error {
 		}
 
 		// Validating cluster connectivity
-		if err := ValidateClusterAccess(config.Clusters[ctx.Cluster].Server, config.AuthInfos[ctx.AuthInfo].Token); err != nil {
+		if err := ValidateContextAccess(config.Clusters[ctx.Cluster], config.AuthInfos[ctx.AuthInfo]); err != nil {
 			contextsToRemove = append(contextsToRemove, ctxName)
 		}
 	}
@@ -2,14 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 // DeleteContext removes Kubernetes contexts matching the given name or wildcard pattern.
+// With cascade, contexts discovered through a matched context are removed as well.
 // It cleans up orphaned resources using CleanContext and manages program output.
-func DeleteContext(namePattern string) error {
+func DeleteContext(namePattern string, cascade bool) error {
 	const op = "kubeconfig.DeleteContext"
 
 	// Validating input pattern
@@ -48,6 +50,24 @@ func DeleteContext(namePattern string) error {
 		matchedContexts = []string{namePattern}
 	}
 
+	// Adding contexts discovered through the matched contexts
+	if cascade {
+		matched := make(map[string]bool, len(matchedContexts))
+		for _, ctxName := range matchedContexts {
+			matched[ctxName] = true
+		}
+		for _, ctxName := range matchedContexts {
+			for descendant := range descendantContexts(config, ctxName) {
+				matched[descendant] = true
+			}
+		}
+		matchedContexts = matchedContexts[:0]
+		for ctxName := range matched {
+			matchedContexts = append(matchedContexts, ctxName)
+		}
+	}
+	sort.Strings(matchedContexts)
+
 	// Checking if changes are needed
 	currentModified := false
 	for _, ctxName := range matchedContexts {
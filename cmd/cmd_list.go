@@ -3,6 +3,10 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd/api"
 )
 
 // ListContexts displays all Kubernetes contexts and identifies orphaned resources.
@@ -40,20 +44,27 @@ func ListContexts() error {
 		}
 		sort.Strings(contextNames)
 
+		// Grouping discovered contexts under the context they were discovered through
+		children := make(map[string][]string)
+		var roots []string
 		for _, ctxName := range contextNames {
-			ctx := config.Contexts[ctxName]
-			fmt.Printf("\n\033[32m● %s\033[0m\n", ctxName)
-			fmt.Printf("  ├─ \033[33mCluster:\033[0m %s", ctx.Cluster)
-			if cluster, ok := config.Clusters[ctx.Cluster]; ok {
-				fmt.Printf(" (%s)", cluster.Server)
+			meta, _ := GetContextMetadata(config.Contexts[ctxName])
+			if _, ok := config.Contexts[meta.Parent]; ok && meta.Parent != ctxName {
+				children[meta.Parent] = append(children[meta.Parent], ctxName)
 			} else {
-				fmt.Printf(" \033[31m(missing)\033[0m")
+				roots = append(roots, ctxName)
 			}
-			fmt.Printf("\n  └─ \033[33mUser:\033[0m %s", ctx.AuthInfo)
-			if _, ok := config.AuthInfos[ctx.AuthInfo]; !ok {
-				fmt.Printf(" \033[31m(missing)\033[0m")
+		}
+
+		printed := make(map[string]bool)
+		for _, ctxName := range roots {
+			printContextEntry(config, ctxName, "", children, printed)
+		}
+		// Printing contexts whose recorded parents form a cycle
+		for _, ctxName := range contextNames {
+			if !printed[ctxName] {
+				printContextEntry(config, ctxName, "", children, printed)
 			}
-			fmt.Println()
 		}
 	}
 
@@ -100,3 +111,92 @@ func ListContexts() error {
 
 	return nil
 }
+
+// printContextEntry prints a context with its cluster, user and metadata, followed by the
+// contexts discovered through it, indented below it.
+func printContextEntry(config *api.Config, ctxName, indent string, children map[string][]string, printed map[string]bool) {
+	printed[ctxName] = true
+	ctx := config.Contexts[ctxName]
+	meta, metaErr := GetContextMetadata(ctx)
+
+	// Displaying the context with its platform
+	fmt.Printf("\n%s\033[32m● %s\033[0m", indent, ctxName)
+	if meta.Scan != nil {
+		fmt.Printf(" \033[36m[scan: %s]\033[0m", meta.Scan.Type)
+	}
+	if meta.Scanner != "" {
+		subCluster := meta.SubCluster
+		if subCluster == "" {
+			subCluster = ctxName
+		}
+		fmt.Printf(" \033[36m(%s: %s)\033[0m", meta.Scanner, subCluster)
+	}
+	fmt.Println()
+
+	// Collecting detail lines
+	var lines []string
+	clusterLine := fmt.Sprintf("\033[33mCluster:\033[0m %s", ctx.Cluster)
+	if cluster, ok := config.Clusters[ctx.Cluster]; ok {
+		clusterLine += fmt.Sprintf(" (%s)", cluster.Server)
+	} else {
+		clusterLine += " \033[31m(missing)\033[0m"
+	}
+	lines = append(lines, clusterLine)
+	if metaErr != nil {
+		lines = append(lines, fmt.Sprintf("\033[31mMetadata: %v\033[0m", metaErr))
+	}
+	userLine := fmt.Sprintf("\033[33mUser:\033[0m %s", ctx.AuthInfo)
+	if _, ok := config.AuthInfos[ctx.AuthInfo]; !ok {
+		userLine += " \033[31m(missing)\033[0m"
+	}
+	lines = append(lines, userLine)
+	if meta.DisplayName != "" {
+		lines = append(lines, fmt.Sprintf("\033[33mDisplay Name:\033[0m %s", meta.DisplayName))
+	}
+	if meta.Version != "" || meta.Phase != "" {
+		var status []string
+		if meta.Version != "" {
+			status = append(status, meta.Version)
+		}
+		if meta.Phase == "Running" {
+			status = append(status, meta.Phase)
+		} else if meta.Phase != "" {
+			status = append(status, fmt.Sprintf("\033[31m%s\033[0m", meta.Phase))
+		}
+		lines = append(lines, fmt.Sprintf("\033[33mStatus:\033[0m %s", strings.Join(status, ", ")))
+	}
+	if len(meta.Labels) > 0 {
+		var labels []string
+		for key, value := range meta.Labels {
+			labels = append(labels, key+"="+value)
+		}
+		sort.Strings(labels)
+		lines = append(lines, fmt.Sprintf("\033[33mLabels:\033[0m %s", strings.Join(labels, ", ")))
+	}
+	if meta.ExpiresAt != nil {
+		expiresLine := fmt.Sprintf("\033[33mExpires:\033[0m %s", meta.ExpiresAt.Local().Format(time.RFC3339))
+		if remaining := time.Until(*meta.ExpiresAt); remaining <= 0 {
+			expiresLine += " \033[31m(expired)\033[0m"
+		} else if meta.Expiring() {
+			expiresLine += fmt.Sprintf(" \033[33m(expires in %s)\033[0m", remaining.Round(time.Minute))
+		}
+		lines = append(lines, expiresLine)
+	}
+	if meta.QuarantinedAt != nil {
+		lines = append(lines, fmt.Sprintf("\033[31mQuarantined:\033[0m %s (sub-cluster no longer found by refresh)", meta.QuarantinedAt.Local().Format(time.RFC3339)))
+	}
+	for i, line := range lines {
+		branch := "├─"
+		if i == len(lines)-1 {
+			branch = "└─"
+		}
+		fmt.Printf("%s  %s %s\n", indent, branch, line)
+	}
+
+	// Displaying discovered contexts
+	for _, child := range children[ctxName] {
+		if !printed[child] {
+			printContextEntry(config, child, indent+"    ", children, printed)
+		}
+	}
+}
@@ -2,28 +2,55 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
 )
 
 // ContextConfig represents a context configuration for merging or scanning
 type ContextConfig struct {
-	Name   string
-	Server string
-	Token  string
+	Name    string `json:"name"`
+	Server  string `json:"server"`
+	Token   string `json:"token"`
+	Warning string `json:"warning,omitempty"` // Reported in the summary, e.g. for unhealthy sub-clusters
+
+	// Sub-cluster name and labels on the platform, used by scan filters
+	SubCluster string            `json:"subCluster,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+
+	// Full cluster and user entries, used instead of Server/Token when set
+	Cluster  *api.Cluster  `json:"-"`
+	AuthInfo *api.AuthInfo `json:"-"`
+
+	// Metadata recorded with the context
+	Metadata ContextMetadata `json:"metadata"`
+
+	// Timeout of API requests made while scanning through this context, set by Scan
+	timeout time.Duration
 }
 
 // MergeContext handles the merge command, merging contexts from an external kubeconfig file.
 // It validates inputs, scans for sub-clusters if requested, and manages program output.
-func MergeContext(filePath, namePrefix string, scan *string) error {
+// Cluster entries keep their TLS and connection settings unless insecure is set. Contexts whose name
+// is already taken are handled according to onConflict (see ConflictStrategies).
+func MergeContext(filePath, namePrefix string, insecure bool, onConflict string, scan *string, opts ScanOptions) error {
 	const op = "kubeconfig.MergeContext"
 
 	// Validating input
 	if filePath == "" {
 		return fmt.Errorf("%s: kubeconfig file path cannot be empty", op)
 	}
+	if onConflict == "" {
+		onConflict = ConflictFail
+	}
+	if !ValidConflictStrategy(onConflict) {
+		return fmt.Errorf("%s: unknown conflict strategy %q, must be one of: %v", op, onConflict, ConflictStrategies)
+	}
 
 	// Loading current kubeconfig
 	currentConfig, _, err := GetKubeConfig()
@@ -36,10 +63,14 @@ func MergeContext(filePath, namePrefix string, scan *string) error {
 	if err != nil {
 		return fmt.Errorf("%s: failed to parse external kubeconfig %s: %w", op, filePath, err)
 	}
+	// Resolving relative certificate and key paths against the external kubeconfig's directory
+	if err := clientcmd.ResolveLocalPaths(externalConfig); err != nil {
+		return fmt.Errorf("%s: failed to resolve paths in external kubeconfig %s: %w", op, filePath, err)
+	}
 
 	// Collecting valid contexts
 	var configs []ContextConfig
-	var certificateContexts []string
+	skippedCount := 0
 
 	for ctxName, ctx := range externalConfig.Contexts {
 		// Validating associated cluster and user
@@ -48,74 +79,209 @@ func MergeContext(filePath, namePrefix string, scan *string) error {
 		if !cExists || !aExists {
 			fmt.Printf("\033[33m[%s] Skipped context %s: missing resources (cluster: %t, user: %t)\033[0m\n",
 				op, ctxName, cExists, aExists)
+			skippedCount++
 			continue
 		}
 
-		// Skipping certificate-based authentication
-		if authInfo.ClientCertificateData != nil || authInfo.ClientCertificate != "" {
-			certificateContexts = append(certificateContexts, ctxName)
+		// Copying cluster and user entries with certificates and keys embedded
+		mergedCluster, err := copyCluster(cluster, insecure)
+		if err != nil {
+			fmt.Printf("\033[33m[%s] Skipped context %s: %v\033[0m\n", op, ctxName, err)
+			skippedCount++
+			continue
+		}
+		mergedAuthInfo, err := copyAuthInfo(authInfo)
+		if err != nil {
+			fmt.Printf("\033[33m[%s] Skipped context %s: %v\033[0m\n", op, ctxName, err)
+			skippedCount++
 			continue
 		}
 
 		// Generating final context name
-		finalCtxName := ctxName
+		filename := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+		defaultName := fmt.Sprintf("%s-%s", filename, ctxName)
 		if namePrefix != "" {
-			finalCtxName = fmt.Sprintf("%s-%s", namePrefix, ctxName)
-		} else {
-			filename := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
-			finalCtxName = fmt.Sprintf("%s-%s", filename, ctxName)
+			defaultName = fmt.Sprintf("%s-%s", namePrefix, ctxName)
 		}
-
-		configs = append(configs, ContextConfig{
-			Name:   finalCtxName,
-			Server: cluster.Server,
-			Token:  authInfo.Token,
+		finalCtxName, err := contextName(opts.NameTemplate, NameData{
+			Name:    defaultName,
+			File:    filename,
+			Prefix:  namePrefix,
+			Context: ctxName,
+			Cluster: ctx.Cluster,
+			User:    ctx.AuthInfo,
+			Host:    serverHost(cluster.Server),
 		})
-	}
+		if err != nil {
+			fmt.Printf("\033[33m[%s] Skipped context %s: %v\033[0m\n", op, ctxName, err)
+			skippedCount++
+			continue
+		}
 
-	// Reporting skipped certificate-based contexts
-	if len(certificateContexts) > 0 {
-		fmt.Printf("\033[33m[%s] Skipped %d certificate-based contexts: %v\033[0m\n",
-			op, len(certificateContexts), certificateContexts)
+		cfg := ContextConfig{
+			Name:     finalCtxName,
+			Server:   cluster.Server,
+			Token:    authInfo.Token,
+			Cluster:  mergedCluster,
+			AuthInfo: mergedAuthInfo,
+		}
+		if scan != nil {
+			cfg.Metadata.Scan = NewScanRecord(*scan, opts)
+		}
+		configs = append(configs, cfg)
 	}
 
+	// Ordering contexts by name so that output and conflict handling are stable
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name < configs[j].Name })
+
 	// Checking for name conflicts
-	for _, cfg := range configs {
-		if _, exists := currentConfig.Contexts[cfg.Name]; exists {
-			return fmt.Errorf("%s: name conflict detected for context %q; use --name to specify a prefix (e.g., --name=prod)", op, cfg.Name)
+	renamedCount := 0
+	switch onConflict {
+	case ConflictFail:
+		for _, cfg := range configs {
+			if _, exists := currentConfig.Contexts[cfg.Name]; exists && !rescannedContext(currentConfig, cfg) {
+				return fmt.Errorf("%s: name conflict detected for context %q; use --name to specify a prefix (e.g., --name=prod) or --on-conflict", op, cfg.Name)
+			}
+		}
+	case ConflictRename:
+		// Renaming before scanning, so that sub-cluster names follow the new name
+		reserved := make(map[string]bool, len(configs))
+		for _, cfg := range configs {
+			reserved[cfg.Name] = true
+		}
+		for i, cfg := range configs {
+			delete(reserved, cfg.Name)
+			configs[i].Name = availableName(currentConfig, cfg.Name, reserved)
+			reserved[configs[i].Name] = true
+			if configs[i].Name != cfg.Name {
+				renamedCount++
+				fmt.Printf("\033[33m[%s] Renaming context %s to %s: name already exists\033[0m\n", op, cfg.Name, configs[i].Name)
+			}
+		}
+	}
+
+	// Scanning for sub-clusters if requested
+	var scanResults []ScanResult
+	if scan != nil {
+		fmt.Printf("\033[36m[%s] Scanning %d contexts...\033[0m\n", op, len(configs))
+		scanResults = ScanContexts(configs, *scan, opts)
+	}
+
+	// Asking which sub-clusters to add
+	var selectedNames map[string]bool
+	if scan != nil && opts.Interactive {
+		var subContexts []ContextConfig
+		for _, result := range scanResults {
+			subContexts = append(subContexts, result.AllContexts()...)
+		}
+		selected, err := SelectContexts(os.Stdin, subContexts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		selectedNames = make(map[string]bool, len(selected))
+		for _, cfg := range selected {
+			selectedNames[cfg.Name] = true
 		}
 	}
 
-	// Processing contexts with optional scanning
+	// Processing contexts in order
 	fmt.Printf("\033[36m[%s] Merging contexts...\033[0m\n", op)
 	var allConfigs []ContextConfig
+	var scanFailures []ScanFailure
+	renamed := make(map[string]string)
+	var backupPath string
+	attemptedCount := 0
 	successCount := 0
-	for _, cfg := range configs {
+	refreshedCount := 0
+	skippedExistingCount := 0
+	overwrittenCount := 0
+	for i, cfg := range configs {
 		// Adding the primary context
 		var contexts []ContextConfig
 		contexts = append(contexts, cfg)
 
-		// Scanning for sub-clusters if requested
+		// Collecting scanned sub-clusters
 		if scan != nil {
-			scannedContexts, err := Scan(cfg.Name, cfg.Server, cfg.Token, *scan)
-			if err != nil {
-				fmt.Printf("\033[31m  ✗ Failed to scan sub-clusters for %s: %v\033[0m\n", cfg.Name, err)
-				continue
+			result := scanResults[i]
+			for _, f := range result.AllFailures() {
+				fmt.Printf("\033[31m  ✗ Failed to scan sub-clusters for %s with type %q: %v\033[0m\n", f.Context, f.Type, f.Err)
+			}
+			if len(result.Types) == 0 {
+				fmt.Printf("\033[33m[%s] No known platform detected for %s\033[0m\n", op, cfg.Name)
+			} else if len(result.Contexts) == 0 && len(result.Failures) == 0 {
+				fmt.Printf("\033[33m[%s] No sub-clusters found for %s with type %q\033[0m\n", op, cfg.Name, strings.Join(result.Types, ","))
 			}
-			if len(scannedContexts) == 0 {
-				fmt.Printf("\033[33m[%s] No sub-clusters found for %s with type %q\033[0m\n", op, cfg.Name, *scan)
-			} else {
-				contexts = append(contexts, scannedContexts...)
+			for _, sub := range result.AllContexts() {
+				if selectedNames == nil || selectedNames[sub.Name] {
+					contexts = append(contexts, sub)
+				}
 			}
+			scanFailures = append(scanFailures, result.AllFailures()...)
 		}
 
 		// Adding all contexts
 		for _, ctx := range contexts {
-			if err := NewContext(ctx.Name, ctx.Server, ctx.Token); err != nil {
+			if newName, ok := renamed[ctx.Metadata.Parent]; ok {
+				ctx.Metadata.Parent = newName
+			}
+			attemptedCount++
+			if refreshed, err := RefreshContext(ctx); err != nil {
+				fmt.Printf("\033[31m  ✗ Failed to refresh context %s: %v\033[0m\n", ctx.Name, err)
+				continue
+			} else if refreshed {
+				fmt.Printf("\033[32m  ↻ Refreshed context: %s (%s)\033[0m\n", ctx.Name, ctx.Server)
+				refreshedCount++
+				continue
+			}
+
+			// Resolving name conflicts
+			config, kubeconfigPath, err := GetKubeConfig()
+			if err != nil {
+				fmt.Printf("\033[31m  ✗ Failed to add context %s: %v\033[0m\n", ctx.Name, err)
+				continue
+			}
+			if onConflict == ConflictFail && rescannedContext(config, ctx) {
+				fmt.Printf("\033[33m  - Skipped context: %s (already exists)\033[0m\n", ctx.Name)
+				skippedExistingCount++
+				continue
+			}
+			if onConflict != ConflictFail && CheckNameConflicts(config, ctx.Name) != nil {
+				switch onConflict {
+				case ConflictSkip:
+					fmt.Printf("\033[33m  - Skipped context: %s (already exists)\033[0m\n", ctx.Name)
+					skippedExistingCount++
+					continue
+				case ConflictOverwrite:
+					if backupPath == "" {
+						if backupPath, err = BackupKubeConfig(config, kubeconfigPath); err != nil {
+							fmt.Printf("\033[31m  ✗ Failed to overwrite context %s: %v\033[0m\n", ctx.Name, err)
+							continue
+						}
+					}
+					if err := OverwriteContext(ctx); err != nil {
+						fmt.Printf("\033[31m  ✗ Failed to overwrite context %s: %v\033[0m\n", ctx.Name, err)
+						continue
+					}
+					fmt.Printf("\033[32m  ⟳ Overwrote context: %s (%s)\033[0m\n", ctx.Name, ctx.Server)
+					overwrittenCount++
+					allConfigs = append(allConfigs, ctx)
+					continue
+				case ConflictRename:
+					newName := availableName(config, ctx.Name, nil)
+					fmt.Printf("\033[33m  → Renamed context %s to %s (already exists)\033[0m\n", ctx.Name, newName)
+					renamed[ctx.Name] = newName
+					renamedCount++
+					ctx.Name = newName
+				}
+			}
+			if err := NewContext(ctx); err != nil {
 				fmt.Printf("\033[31m  ✗ Failed to add context %s: %v\033[0m\n", ctx.Name, err)
 				continue
 			}
 			fmt.Printf("\033[32m  ✓ Added context: %s (%s)\033[0m\n", ctx.Name, ctx.Server)
+			if ctx.Warning != "" {
+				fmt.Printf("\033[33m    ⚠ %s\033[0m\n", ctx.Warning)
+			}
 			successCount++
 			allConfigs = append(allConfigs, ctx)
 		}
@@ -124,9 +290,124 @@ func MergeContext(filePath, namePrefix string, scan *string) error {
 	// Displaying summary
 	fmt.Printf("\033[36m\n%s Summary:\n", op)
 	fmt.Printf("  ✓ Added contexts: %d\n", successCount)
-	fmt.Printf("  ✗ Skipped certificate-based contexts: %d\n", len(certificateContexts))
-	fmt.Printf("  ✗ Failed contexts: %d\n", len(allConfigs)-successCount)
+	if refreshedCount > 0 {
+		fmt.Printf("  ↻ Refreshed contexts: %d\n", refreshedCount)
+	}
+	if overwrittenCount > 0 {
+		fmt.Printf("  ⟳ Overwritten contexts: %d\n", overwrittenCount)
+	}
+	if renamedCount > 0 {
+		fmt.Printf("  → Renamed contexts: %d\n", renamedCount)
+	}
+	if skippedExistingCount > 0 {
+		fmt.Printf("  - Skipped existing contexts: %d\n", skippedExistingCount)
+	}
+	if skippedCount > 0 {
+		fmt.Printf("  ✗ Skipped invalid contexts: %d\n", skippedCount)
+	}
+	fmt.Printf("  ✗ Failed contexts: %d\n", attemptedCount-successCount-refreshedCount-overwrittenCount-skippedExistingCount)
+	printAuthMethods(allConfigs)
+	printContextWarnings(allConfigs)
+	if scan != nil && opts.Depth > 1 {
+		printScanTree(scanResults)
+	} else if scan != nil && *scan == AutoScan {
+		printScanTypes(scanResults)
+	}
+	if len(scanFailures) > 0 {
+		printScanFailures(scanFailures)
+	}
+	if backupPath != "" {
+		fmt.Printf("  ✓ Backup saved at: %s\n", backupPath)
+	}
 	fmt.Println(strings.Repeat("=", 50) + "\033[0m")
 
 	return nil
+}
+
+// copyCluster returns a complete copy of a cluster entry, including its TLS server name, proxy URL
+// and compression settings, with the certificate authority embedded when it is given as a file.
+// With insecure, TLS verification is skipped and the certificate authority is dropped, as client-go
+// does not accept both. Paths must already be absolute.
+func copyCluster(cluster *api.Cluster, insecure bool) (*api.Cluster, error) {
+	copied := cluster.DeepCopy()
+	copied.LocationOfOrigin = ""
+
+	if insecure {
+		copied.InsecureSkipTLSVerify = true
+		copied.CertificateAuthority, copied.CertificateAuthorityData = "", nil
+		return copied, nil
+	}
+	if len(copied.CertificateAuthorityData) == 0 && copied.CertificateAuthority != "" {
+		data, err := os.ReadFile(copied.CertificateAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate authority: %w", err)
+		}
+		copied.CertificateAuthorityData = data
+	}
+	copied.CertificateAuthority = ""
+	return copied, nil
+}
+
+// copyAuthInfo returns a complete copy of user credentials, including exec and auth-provider
+// settings, with the client certificate and key embedded when they are given as files.
+// Paths must already be absolute.
+func copyAuthInfo(authInfo *api.AuthInfo) (*api.AuthInfo, error) {
+	copied := authInfo.DeepCopy()
+	copied.LocationOfOrigin = ""
+
+	if len(copied.ClientCertificateData) == 0 && copied.ClientCertificate != "" {
+		data, err := os.ReadFile(copied.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		copied.ClientCertificateData = data
+	}
+	if len(copied.ClientKeyData) == 0 && copied.ClientKey != "" {
+		data, err := os.ReadFile(copied.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %w", err)
+		}
+		copied.ClientKeyData = data
+	}
+	copied.ClientCertificate, copied.ClientKey = "", ""
+	if len(copied.ClientCertificateData) > 0 && len(copied.ClientKeyData) == 0 {
+		return nil, fmt.Errorf("client certificate has no key")
+	}
+	return copied, nil
+}
+
+// authMethod describes how a context authenticates (e.g., "exec (aws)", "token").
+func authMethod(cfg ContextConfig) string {
+	authInfo := cfg.AuthInfo
+	if authInfo == nil {
+		authInfo = &api.AuthInfo{Token: cfg.Token}
+	}
+
+	switch {
+	case authInfo.Exec != nil:
+		return fmt.Sprintf("exec (%s)", filepath.Base(authInfo.Exec.Command))
+	case authInfo.AuthProvider != nil:
+		return fmt.Sprintf("auth-provider (%s)", authInfo.AuthProvider.Name)
+	case len(authInfo.ClientCertificateData) > 0:
+		return "client-certificate"
+	case authInfo.Token != "":
+		return "token"
+	case authInfo.TokenFile != "":
+		return "token-file"
+	case authInfo.Username != "":
+		return "basic"
+	default:
+		return "none"
+	}
+}
+
+// printAuthMethods prints the authentication method of each context as part of a command summary.
+func printAuthMethods(configs []ContextConfig) {
+	if len(configs) == 0 {
+		return
+	}
+	fmt.Printf("  ✓ Auth methods:\n")
+	for _, cfg := range configs {
+		fmt.Printf("    - %s: %s\n", cfg.Name, authMethod(cfg))
+	}
 }
\ No newline at end of file
@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// RefreshPlatform handles the refresh command, re-scanning a context added with --scan using its recorded
+// scan settings. It adds contexts for new sub-clusters, updates changed ones, and removes (or quarantines)
+// contexts whose sub-cluster is gone. Sub-clusters are matched with existing contexts through their recorded
+// parent, scanner and sub-cluster name, so renamed contexts are kept. Removal is skipped when any detection,
+// scan or context update fails, or when no scanner ran at all, so that a transient error does not prune contexts.
+func RefreshPlatform(name string, quarantine bool, timeout time.Duration) error {
+	const op = "kubeconfig.RefreshPlatform"
+
+	// Validating input
+	if name == "" {
+		return fmt.Errorf("%s: context name cannot be empty", op)
+	}
+
+	// Loading kubeconfig
+	config, kubeconfigPath, err := GetKubeConfig()
+	if err != nil {
+		return fmt.Errorf("%s: failed to load kubeconfig: %w", op, err)
+	}
+
+	// Reading the recorded scan settings
+	parent, err := contextConfigFrom(config, name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if parent.Metadata.Scan == nil {
+		return fmt.Errorf("%s: context %q was not added with --scan", op, name)
+	}
+	record := parent.Metadata.Scan
+	opts, err := record.Options()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	opts.Timeout = timeout
+
+	// Re-scanning the platform
+	fmt.Printf("\033[36m[%s] Scanning %s with type %q...\033[0m\n", op, name, record.Type)
+	result := ScanContext(parent, record.Type, opts)
+	failures := result.AllFailures()
+	for _, f := range failures {
+		fmt.Printf("\033[31m[%s] Failed to scan sub-clusters for %s with type %q: %v\033[0m\n", op, f.Context, f.Type, f.Err)
+	}
+
+	// Creating backup before changes
+	backupPath, err := BackupKubeConfig(config, kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("%s: failed to create backup: %w", op, err)
+	}
+
+	// Reconciling discovered sub-clusters with existing contexts
+	fmt.Printf("\033[36m[%s] Reconciling contexts...\033[0m\n", op)
+	existing := make(map[string]ContextMetadata)
+	index := make(map[string]string)
+	for ctxName := range descendantContexts(config, name) {
+		meta, _ := GetContextMetadata(config.Contexts[ctxName])
+		existing[ctxName] = meta
+		index[subClusterKey(meta, ctxName)] = ctxName
+	}
+	stored := map[string]string{parent.Name: parent.Name} // Discovered names to the names kept in the kubeconfig
+	var added, updated, removed, quarantined, unchanged, failed int
+	for _, cfg := range result.AllContexts() {
+		discovered := cfg.Name
+		if p, ok := stored[cfg.Metadata.Parent]; ok {
+			cfg.Metadata.Parent = p
+		}
+
+		// Matching the sub-cluster with the context it was stored as, which may have been renamed
+		ctxName, ok := index[subClusterKey(cfg.Metadata, cfg.Name)]
+		if !ok {
+			if meta, found := existing[cfg.Name]; found && meta.SubCluster == "" {
+				ctxName, ok = cfg.Name, true
+			}
+		}
+		if !ok {
+			if err := CheckNameConflicts(config, cfg.Name); err != nil {
+				fmt.Printf("\033[31m  ✗ %s: %v\033[0m\n", cfg.Name, err)
+				failed++
+				continue
+			}
+			if err := setContextEntries(config, cfg); err != nil {
+				fmt.Printf("\033[31m  ✗ %s: %v\033[0m\n", cfg.Name, err)
+				failed++
+				continue
+			}
+			stored[discovered] = cfg.Name
+			fmt.Printf("\033[32m  + %s (%s)\033[0m\n", cfg.Name, cfg.Server)
+			added++
+			continue
+		}
+		stored[discovered] = ctxName
+		meta := existing[ctxName]
+		delete(existing, ctxName)
+
+		// Leaving unchanged contexts as they are, apart from their recorded metadata
+		if meta.QuarantinedAt == nil && sameContextEntries(config, ctxName, cfg) {
+			if !equality.Semantic.DeepEqual(meta, cfg.Metadata) {
+				if err := SetContextMetadata(config.Contexts[ctxName], cfg.Metadata); err != nil {
+					fmt.Printf("\033[31m  ✗ %s: %v\033[0m\n", ctxName, err)
+					failed++
+					continue
+				}
+			}
+			unchanged++
+			continue
+		}
+		cfg.Name = ctxName
+		if err := updateContextEntries(config, ctxName, cfg); err != nil {
+			fmt.Printf("\033[31m  ✗ %s: %v\033[0m\n", ctxName, err)
+			failed++
+			continue
+		}
+		fmt.Printf("\033[33m  ~ %s (%s)\033[0m\n", ctxName, cfg.Server)
+		updated++
+	}
+
+	// Removing or quarantining contexts whose sub-cluster is gone
+	var gone []string
+	for ctxName := range existing {
+		gone = append(gone, ctxName)
+	}
+	sort.Strings(gone)
+	if len(gone) > 0 && (len(failures) > 0 || failed > 0) {
+		fmt.Printf("\033[33m[%s] Kept %d contexts not found in this scan because some scans or contexts failed\033[0m\n", op, len(gone))
+		gone = nil
+	} else if len(gone) > 0 && len(result.Types) == 0 {
+		fmt.Printf("\033[33m[%s] Kept %d contexts not found in this scan because no platform was detected\033[0m\n", op, len(gone))
+		gone = nil
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, ctxName := range gone {
+		if !quarantine {
+			delete(config.Contexts, ctxName)
+			if config.CurrentContext == ctxName {
+				config.CurrentContext = ""
+			}
+			fmt.Printf("\033[31m  - %s\033[0m\n", ctxName)
+			removed++
+			continue
+		}
+
+		ctx := config.Contexts[ctxName]
+		meta, err := GetContextMetadata(ctx)
+		if err != nil {
+			fmt.Printf("\033[31m  ✗ %s: %v\033[0m\n", ctxName, err)
+			failed++
+			continue
+		}
+		if meta.QuarantinedAt != nil {
+			continue
+		}
+		meta.QuarantinedAt = &now
+		if err := SetContextMetadata(ctx, meta); err != nil {
+			fmt.Printf("\033[31m  ✗ %s: %v\033[0m\n", ctxName, err)
+			failed++
+			continue
+		}
+		fmt.Printf("\033[33m  ! %s (quarantined)\033[0m\n", ctxName)
+		quarantined++
+	}
+
+	// Cleaning up orphaned resources
+	removedClusters, removedUsers, err := CleanContext(config)
+	if err != nil {
+		return fmt.Errorf("%s: failed to clean orphaned resources: %w", op, err)
+	}
+
+	// Saving updated configuration
+	if err := SafeWriteConfig(config, kubeconfigPath); err != nil {
+		return fmt.Errorf("%s: failed to save kubeconfig to %s: %w", op, kubeconfigPath, err)
+	}
+
+	// Displaying summary
+	fmt.Printf("\033[36m\n%s Summary:\n", op)
+	fmt.Printf("  + Added contexts: %d\n", added)
+	fmt.Printf("  ~ Updated contexts: %d\n", updated)
+	if quarantine {
+		fmt.Printf("  ! Quarantined contexts: %d\n", quarantined)
+	} else {
+		fmt.Printf("  - Removed contexts: %d\n", removed)
+	}
+	fmt.Printf("  = Unchanged contexts: %d\n", unchanged)
+	if failed > 0 {
+		fmt.Printf("  ✗ Failed contexts: %d\n", failed)
+	}
+	if len(removedClusters) > 0 || len(removedUsers) > 0 {
+		fmt.Printf("  ✓ Removed clusters: %d, users: %d\n", len(removedClusters), len(removedUsers))
+	}
+	if opts.Depth > 1 {
+		printScanTree([]ScanResult{result})
+	} else if record.Type == AutoScan {
+		printScanTypes([]ScanResult{result})
+	}
+	if len(failures) > 0 {
+		printScanFailures(failures)
+	}
+	fmt.Printf("  ✓ Backup saved at: %s\n", backupPath)
+	fmt.Println(strings.Repeat("=", 50) + "\033[0m")
+
+	return nil
+}
+
+// contextConfigFrom builds a context configuration from an existing context and its cluster and user entries.
+func contextConfigFrom(config *api.Config, name string) (ContextConfig, error) {
+	ctx, ok := config.Contexts[name]
+	if !ok {
+		return ContextConfig{}, fmt.Errorf("context %q does not exist", name)
+	}
+	cluster, ok := config.Clusters[ctx.Cluster]
+	if !ok {
+		return ContextConfig{}, fmt.Errorf("cluster %q of context %q does not exist", ctx.Cluster, name)
+	}
+	authInfo, ok := config.AuthInfos[ctx.AuthInfo]
+	if !ok {
+		return ContextConfig{}, fmt.Errorf("user %q of context %q does not exist", ctx.AuthInfo, name)
+	}
+	meta, err := GetContextMetadata(ctx)
+	if err != nil {
+		return ContextConfig{}, err
+	}
+
+	return ContextConfig{
+		Name:     name,
+		Server:   cluster.Server,
+		Token:    authInfo.Token,
+		Cluster:  cluster.DeepCopy(),
+		AuthInfo: authInfo.DeepCopy(),
+		Metadata: meta,
+	}, nil
+}
+
+// subClusterKey identifies a discovered context by its parent, scanner and sub-cluster as recorded in
+// its metadata, falling back to the context name for contexts recorded without a sub-cluster.
+func subClusterKey(meta ContextMetadata, name string) string {
+	if meta.SubCluster != "" {
+		name = meta.SubCluster
+	}
+	return meta.Parent + "\x00" + meta.Scanner + "\x00" + name
+}
+
+// sameContextEntries reports whether the cluster and user entries of the named context
+// already match what the configuration would write.
+func sameContextEntries(config *api.Config, name string, cfg ContextConfig) bool {
+	want := api.NewConfig()
+	if err := setContextEntries(want, cfg); err != nil {
+		return false
+	}
+
+	ctx := config.Contexts[name]
+	cluster, cOK := config.Clusters[ctx.Cluster]
+	authInfo, aOK := config.AuthInfos[ctx.AuthInfo]
+	if !cOK || !aOK {
+		return false
+	}
+
+	gotCluster, gotAuthInfo := cluster.DeepCopy(), authInfo.DeepCopy()
+	wantCluster, wantAuthInfo := want.Clusters[cfg.Name], want.AuthInfos[cfg.Name]
+	gotCluster.LocationOfOrigin, wantCluster.LocationOfOrigin = "", ""
+	gotAuthInfo.LocationOfOrigin, wantAuthInfo.LocationOfOrigin = "", ""
+	return equality.Semantic.DeepEqual(gotCluster, wantCluster) && equality.Semantic.DeepEqual(gotAuthInfo, wantAuthInfo)
+}
@@ -89,6 +89,57 @@ func ValidateClusterAccess(server, token string) error {
 	return nil
 }
 
+// ValidateContextAccess verifies connectivity to a Kubernetes cluster using full kubeconfig cluster and user entries,
+// so that certificate-based users are validated as well as token-based ones.
+func ValidateContextAccess(cluster *api.Cluster, authInfo *api.AuthInfo) error {
+	const op = "kubeconfig.ValidateContextAccess"
+
+	// Validating input parameters
+	if cluster == nil || cluster.Server == "" {
+		return fmt.Errorf("%s: server address cannot be empty", op)
+	}
+	if authInfo == nil {
+		return fmt.Errorf("%s: user cannot be nil", op)
+	}
+
+	// Configuring REST client
+	restConfig, err := restConfigFor(cluster, authInfo)
+	if err != nil {
+		return fmt.Errorf("%s: failed to build client config for %s: %w", op, cluster.Server, err)
+	}
+
+	// Creating Kubernetes client
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, cluster.Server, err)
+	}
+
+	// Testing API access by listing namespaces
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{}); err != nil {
+		return fmt.Errorf("%s: failed to access API at %s: %w", op, cluster.Server, err)
+	}
+
+	return nil
+}
+
+// restConfigFor builds a REST client configuration from kubeconfig cluster and user entries.
+func restConfigFor(cluster *api.Cluster, authInfo *api.AuthInfo) (*rest.Config, error) {
+	config := api.NewConfig()
+	config.Clusters["cluster"] = cluster
+	config.AuthInfos["user"] = authInfo
+	config.Contexts["context"] = &api.Context{Cluster: "cluster", AuthInfo: "user"}
+	config.CurrentContext = "context"
+
+	restConfig, err := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = 5 * time.Second
+	return restConfig, nil
+}
+
 // CheckNameConflicts verifies that the provided name does not conflict with existing clusters, users, or contexts.
 func CheckNameConflicts(config *api.Config, name string) error {
 	const op = "kubeconfig.CheckNameConflicts"
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+// KontextConfig holds user settings loaded from the kontext config file.
+type KontextConfig struct {
+	Scanners     []GenericScannerConfig `json:"scanners,omitempty"`
+	NameTemplate string                 `json:"nameTemplate,omitempty"` // Default for --name-template
+}
+
+// GetKontextConfigPath returns the kontext config file path.
+// It honors $KONTEXT_CONFIG and defaults to ~/.kube/kontext.yaml.
+func GetKontextConfigPath() string {
+	if p := os.Getenv("KONTEXT_CONFIG"); p != "" {
+		return p
+	}
+	return filepath.Join(clientcmd.RecommendedConfigDir, "kontext.yaml")
+}
+
+// LoadKontextConfig loads the kontext config file and returns it with its path.
+// A missing file yields an empty configuration.
+func LoadKontextConfig() (*KontextConfig, string, error) {
+	const op = "kubeconfig.LoadKontextConfig"
+
+	configPath := GetKontextConfigPath()
+
+	// Reading config file
+	configBytes, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return &KontextConfig{}, configPath, nil
+	} else if err != nil {
+		return nil, "", fmt.Errorf("%s: failed to read config file %s: %w", op, configPath, err)
+	}
+
+	// Parsing config file
+	config := &KontextConfig{}
+	if err := yaml.UnmarshalStrict(configBytes, config); err != nil {
+		return nil, "", fmt.Errorf("%s: failed to parse config file %s: %w", op, configPath, err)
+	}
+
+	return config, configPath, nil
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Strategies for merged contexts whose name is already taken in the kubeconfig.
+const (
+	ConflictFail      = "fail"      // Abort the merge before writing anything
+	ConflictSkip      = "skip"      // Keep the existing context
+	ConflictOverwrite = "overwrite" // Replace the existing cluster and user entries, after a backup
+	ConflictRename    = "rename"    // Add the context under the first free name with a numeric suffix
+)
+
+// ConflictStrategies lists the accepted --on-conflict values.
+var ConflictStrategies = []string{ConflictFail, ConflictSkip, ConflictOverwrite, ConflictRename}
+
+// ValidConflictStrategy reports whether the value names a known conflict strategy.
+func ValidConflictStrategy(strategy string) bool {
+	for _, s := range ConflictStrategies {
+		if s == strategy {
+			return true
+		}
+	}
+	return false
+}
+
+// availableName returns the name itself when it is free, otherwise the first of name-2, name-3, ...
+// that is neither used in the kubeconfig nor reserved by other contexts of the same operation.
+func availableName(config *api.Config, name string, reserved map[string]bool) string {
+	candidate := name
+	for i := 2; CheckNameConflicts(config, candidate) != nil || reserved[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
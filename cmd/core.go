@@ -5,23 +5,26 @@ import (
 	"os"
 	"path/filepath"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 )
 
-// NewContext adds a new Kubernetes context with the specified name, server, and token.
-// It performs name conflict checks and saves the configuration, returning any errors.
-func NewContext(name, server, token string) error {
+// NewContext adds a new Kubernetes context from the given context configuration.
+// Configurations that carry full cluster or user entries (e.g., client certificates) are stored as-is;
+// otherwise the server and token are used. It performs name conflict checks and saves the configuration.
+func NewContext(cfg ContextConfig) error {
 	const op = "kubeconfig.NewContext"
 
 	// Validating input parameters
+	name := cfg.Name
 	if name == "" {
 		return fmt.Errorf("%s: context name cannot be empty", op)
 	}
-	if server == "" {
+	if cfg.Server == "" && cfg.Cluster == nil {
 		return fmt.Errorf("%s: server address cannot be empty", op)
 	}
-	if token == "" {
+	if cfg.Token == "" && cfg.AuthInfo == nil {
 		return fmt.Errorf("%s: token cannot be empty", op)
 	}
 
@@ -37,30 +40,205 @@ func NewContext(name, server, token string) error {
 	}
 
 	// Building new configuration
+	if err := setContextEntries(config, cfg); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Setting current context
+	config.CurrentContext = name
+
+	// Saving updated configuration
+	if err := SafeWriteConfig(config, kubeconfigPath); err != nil {
+		return fmt.Errorf("%s: failed to save kubeconfig to %s: %w", op, kubeconfigPath, err)
+	}
+
+	return nil
+}
+
+// RefreshContext replaces an existing context whose short-lived credentials are about to expire, keeping
+// its namespace. It returns false without changes when the context does not exist, has no expiry, or is
+// not expiring yet.
+func RefreshContext(cfg ContextConfig) (bool, error) {
+	const op = "kubeconfig.RefreshContext"
+
+	// Only configurations with an expiry can refresh an existing context
+	if cfg.Metadata.ExpiresAt == nil {
+		return false, nil
+	}
+
+	// Loading kubeconfig
+	config, kubeconfigPath, err := GetKubeConfig()
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to load kubeconfig: %w", op, err)
+	}
+
+	// Checking the existing context's expiry
+	existing, ok := config.Contexts[cfg.Name]
+	if !ok {
+		return false, nil
+	}
+	meta, err := GetContextMetadata(existing)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	if !meta.Expiring() {
+		return false, nil
+	}
+
+	// Replacing cluster, user and context entries
+	if err := updateContextEntries(config, cfg.Name, cfg); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Saving updated configuration
+	if err := SafeWriteConfig(config, kubeconfigPath); err != nil {
+		return false, fmt.Errorf("%s: failed to save kubeconfig to %s: %w", op, kubeconfigPath, err)
+	}
+
+	return true, nil
+}
+
+// rescannedContext reports whether the context of the given configuration already exists from an earlier
+// run of the same scan: a platform context recorded with the same scan type, or a context discovered
+// through the same parent, scanner and sub-cluster. Re-running add or merge keeps such contexts.
+func rescannedContext(config *api.Config, cfg ContextConfig) bool {
+	existing, ok := config.Contexts[cfg.Name]
+	if !ok {
+		return false
+	}
+	meta, err := GetContextMetadata(existing)
+	if err != nil {
+		return false
+	}
+	if cfg.Metadata.Scan != nil {
+		return meta.Scan != nil && meta.Scan.Type == cfg.Metadata.Scan.Type
+	}
+	return cfg.Metadata.Scanner != "" && subClusterKey(meta, cfg.Name) == subClusterKey(cfg.Metadata, cfg.Name)
+}
+
+// OverwriteContext replaces the cluster and user entries of an existing context with those of the
+// given configuration, keeping the context's namespace and without replacing entries of other contexts
+// (see updateContextEntries). Scan settings and the discovering parent recorded with the existing context
+// are kept unless the configuration sets its own. The caller is responsible for backups.
+func OverwriteContext(cfg ContextConfig) error {
+	const op = "kubeconfig.OverwriteContext"
+
+	// Loading kubeconfig
+	config, kubeconfigPath, err := GetKubeConfig()
+	if err != nil {
+		return fmt.Errorf("%s: failed to load kubeconfig: %w", op, err)
+	}
+
+	// Keeping the scan records of the existing context that the new configuration does not set
+	meta, err := GetContextMetadata(config.Contexts[cfg.Name])
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cfg.Metadata.Scan == nil {
+		cfg.Metadata.Scan = meta.Scan
+	}
+	if cfg.Metadata.Parent == "" && cfg.Metadata.Scanner == "" && cfg.Metadata.SubCluster == "" {
+		cfg.Metadata.Parent, cfg.Metadata.Scanner, cfg.Metadata.SubCluster = meta.Parent, meta.Scanner, meta.SubCluster
+	}
+
+	// Replacing cluster, user and context entries
+	if err := updateContextEntries(config, cfg.Name, cfg); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Saving updated configuration
+	if err := SafeWriteConfig(config, kubeconfigPath); err != nil {
+		return fmt.Errorf("%s: failed to save kubeconfig to %s: %w", op, kubeconfigPath, err)
+	}
+
+	return nil
+}
+
+// updateContextEntries replaces the cluster, user and metadata of the named context with those of the
+// configuration. Entries are updated in place under their existing names unless another context shares
+// them; otherwise, and for a context that does not exist yet, they are added under a free name so that
+// no other context's entries are replaced. The context keeps its namespace and other extensions.
+func updateContextEntries(config *api.Config, name string, cfg ContextConfig) error {
+	// Building replacement entries
+	entries := api.NewConfig()
+	if err := setContextEntries(entries, cfg); err != nil {
+		return err
+	}
+	ctx := entries.Contexts[cfg.Name]
+
+	// Locating the entries to replace
+	free := availableName(config, name, nil)
+	ctx.Cluster, ctx.AuthInfo = free, free
+	if existing, ok := config.Contexts[name]; ok {
+		ctx.Namespace = existing.Namespace
+		for key, ext := range existing.Extensions {
+			if _, set := ctx.Extensions[key]; !set && key != metadataExtension {
+				if ctx.Extensions == nil {
+					ctx.Extensions = make(map[string]runtime.Object)
+				}
+				ctx.Extensions[key] = ext
+			}
+		}
+		if existing.Cluster != "" && !sharedEntry(config, name, func(c *api.Context) string { return c.Cluster }) {
+			ctx.Cluster = existing.Cluster
+		}
+		if existing.AuthInfo != "" && !sharedEntry(config, name, func(c *api.Context) string { return c.AuthInfo }) {
+			ctx.AuthInfo = existing.AuthInfo
+		}
+	}
+
+	config.Clusters[ctx.Cluster] = entries.Clusters[cfg.Name]
+	config.AuthInfos[ctx.AuthInfo] = entries.AuthInfos[cfg.Name]
+	config.Contexts[name] = ctx
+	return nil
+}
+
+// sharedEntry reports whether the cluster or user entry (selected by entry) of the named context
+// is also referenced by another context.
+func sharedEntry(config *api.Config, name string, entry func(*api.Context) string) bool {
+	target := entry(config.Contexts[name])
+	for ctxName, ctx := range config.Contexts {
+		if ctxName != name && entry(ctx) == target {
+			return true
+		}
+	}
+	return false
+}
+
+// setContextEntries writes the cluster, user and context entries of a context configuration,
+// all named after the context, into the kubeconfig.
+func setContextEntries(config *api.Config, cfg ContextConfig) error {
+	name := cfg.Name
+
 	// Adding cluster
-	cluster := api.NewCluster()
-	cluster.Server = server
-	cluster.InsecureSkipTLSVerify = true // Note: Consider making TLS verification configurable
+	var cluster *api.Cluster
+	if cfg.Cluster != nil {
+		cluster = cfg.Cluster.DeepCopy()
+	} else {
+		cluster = api.NewCluster()
+		cluster.Server = cfg.Server
+		cluster.InsecureSkipTLSVerify = true // Note: Consider making TLS verification configurable
+	}
 	config.Clusters[name] = cluster
 
 	// Adding user credentials
-	authInfo := api.NewAuthInfo()
-	authInfo.Token = token
+	var authInfo *api.AuthInfo
+	if cfg.AuthInfo != nil {
+		authInfo = cfg.AuthInfo.DeepCopy()
+	} else {
+		authInfo = api.NewAuthInfo()
+		authInfo.Token = cfg.Token
+	}
 	config.AuthInfos[name] = authInfo
 
-	// Adding context
+	// Adding context with kontext metadata
 	ctx := api.NewContext()
 	ctx.Cluster = name
 	ctx.AuthInfo = name
-	config.Contexts[name] = ctx
-
-	// Setting current context
-	config.CurrentContext = name
-
-	// Saving updated configuration
-	if err := SafeWriteConfig(config, kubeconfigPath); err != nil {
-		return fmt.Errorf("%s: failed to save kubeconfig to %s: %w", op, kubeconfigPath, err)
+	if err := SetContextMetadata(ctx, cfg.Metadata); err != nil {
+		return err
 	}
+	config.Contexts[name] = ctx
 
 	return nil
 }
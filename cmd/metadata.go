@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// metadataExtension is the kubeconfig context extension under which kontext stores its metadata.
+const metadataExtension = "kontext"
+
+// expiryRefreshWindow is how close to expiry a context must be for a re-scan to replace it.
+const expiryRefreshWindow = time.Hour
+
+// ContextMetadata is the kontext-specific information recorded with a context.
+type ContextMetadata struct {
+	ExpiresAt     *time.Time  `json:"expiresAt,omitempty"`     // Expiry of short-lived credentials
+	Parent        string      `json:"parent,omitempty"`        // Context through which this context was discovered
+	Scanner       string      `json:"scanner,omitempty"`       // Scan type that discovered this context
+	SubCluster    string      `json:"subCluster,omitempty"`    // Sub-cluster name on the parent platform
+	Scan          *ScanRecord `json:"scan,omitempty"`          // Scan settings of a platform context, replayed by refresh
+	QuarantinedAt *time.Time  `json:"quarantinedAt,omitempty"` // When refresh last found the sub-cluster gone
+
+	// Sub-cluster details reported by the platform at scan time
+	DisplayName string            `json:"displayName,omitempty"` // Name shown in the platform UI
+	Labels      map[string]string `json:"labels,omitempty"`      // Labels of the sub-cluster resource (e.g., environment, region)
+	Version     string            `json:"version,omitempty"`     // Kubernetes version
+	Phase       string            `json:"phase,omitempty"`       // Lifecycle phase (e.g., Running)
+}
+
+// ScanRecord holds the scan settings a platform context was added with.
+type ScanRecord struct {
+	Type          string   `json:"type"`
+	Namespace     string   `json:"namespace,omitempty"`
+	URLTemplate   string   `json:"urlTemplate,omitempty"`
+	Expiration    string   `json:"expiration,omitempty"`
+	Depth         int      `json:"depth,omitempty"`
+	Include       []string `json:"include,omitempty"`
+	Exclude       []string `json:"exclude,omitempty"`
+	SkipUnhealthy bool     `json:"skipUnhealthy,omitempty"`
+	Direct        bool     `json:"direct,omitempty"`
+	NameTemplate  string   `json:"nameTemplate,omitempty"`
+}
+
+// NewScanRecord records the scan type and the options that affect which sub-clusters are found.
+func NewScanRecord(clusterType string, opts ScanOptions) *ScanRecord {
+	record := &ScanRecord{
+		Type:          clusterType,
+		Namespace:     opts.Namespace,
+		URLTemplate:   opts.URLTemplate,
+		SkipUnhealthy: opts.SkipUnhealthy,
+		Direct:        opts.Direct,
+		NameTemplate:  opts.NameTemplate.String(),
+	}
+	if opts.Expiration > 0 {
+		record.Expiration = opts.Expiration.String()
+	}
+	if opts.Depth > 1 {
+		record.Depth = opts.Depth
+	}
+	for _, f := range opts.Include {
+		record.Include = append(record.Include, f.String())
+	}
+	for _, f := range opts.Exclude {
+		record.Exclude = append(record.Exclude, f.String())
+	}
+	return record
+}
+
+// Options rebuilds the scan options from the record.
+func (r ScanRecord) Options() (ScanOptions, error) {
+	opts := ScanOptions{
+		Namespace:     r.Namespace,
+		URLTemplate:   r.URLTemplate,
+		Depth:         r.Depth,
+		SkipUnhealthy: r.SkipUnhealthy,
+		Direct:        r.Direct,
+	}
+	if r.Expiration != "" {
+		expiration, err := time.ParseDuration(r.Expiration)
+		if err != nil {
+			return opts, fmt.Errorf("invalid recorded expiration %q: %w", r.Expiration, err)
+		}
+		opts.Expiration = expiration
+	}
+	if r.NameTemplate != "" {
+		nameTemplate, err := ParseNameTemplate(r.NameTemplate)
+		if err != nil {
+			return opts, err
+		}
+		opts.NameTemplate = nameTemplate
+	}
+	for _, pattern := range r.Include {
+		f, err := ParseScanFilter(pattern)
+		if err != nil {
+			return opts, err
+		}
+		opts.Include = append(opts.Include, f)
+	}
+	for _, pattern := range r.Exclude {
+		f, err := ParseScanFilter(pattern)
+		if err != nil {
+			return opts, err
+		}
+		opts.Exclude = append(opts.Exclude, f)
+	}
+	return opts, nil
+}
+
+// IsZero reports whether the metadata carries no information.
+func (m ContextMetadata) IsZero() bool {
+	return m.ExpiresAt == nil && m.Parent == "" && m.Scanner == "" && m.SubCluster == "" && m.Scan == nil && m.QuarantinedAt == nil &&
+		m.DisplayName == "" && len(m.Labels) == 0 && m.Version == "" && m.Phase == ""
+}
+
+// Expiring reports whether the credentials expire within the refresh window.
+func (m ContextMetadata) Expiring() bool {
+	return m.ExpiresAt != nil && time.Until(*m.ExpiresAt) < expiryRefreshWindow
+}
+
+// GetContextMetadata reads the kontext metadata stored in a context's extensions.
+func GetContextMetadata(ctx *api.Context) (ContextMetadata, error) {
+	var meta ContextMetadata
+	if ctx == nil {
+		return meta, nil
+	}
+	ext, ok := ctx.Extensions[metadataExtension]
+	if !ok {
+		return meta, nil
+	}
+	unknown, ok := ext.(*runtime.Unknown)
+	if !ok {
+		return meta, fmt.Errorf("unexpected %s extension type %T", metadataExtension, ext)
+	}
+	if err := json.Unmarshal(unknown.Raw, &meta); err != nil {
+		return meta, fmt.Errorf("failed to parse %s extension: %w", metadataExtension, err)
+	}
+	return meta, nil
+}
+
+// SetContextMetadata stores kontext metadata in a context's extensions, removing it when empty.
+func SetContextMetadata(ctx *api.Context, meta ContextMetadata) error {
+	if meta.IsZero() {
+		delete(ctx.Extensions, metadataExtension)
+		return nil
+	}
+	raw, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s extension: %w", metadataExtension, err)
+	}
+	if ctx.Extensions == nil {
+		ctx.Extensions = make(map[string]runtime.Object)
+	}
+	ctx.Extensions[metadataExtension] = &runtime.Unknown{Raw: raw, ContentType: runtime.ContentTypeJSON}
+	return nil
+}
+
+// descendantContexts returns the contexts discovered through the given context, directly or through
+// other discovered contexts, as recorded in their metadata.
+func descendantContexts(config *api.Config, name string) map[string]bool {
+	parents := make(map[string]string, len(config.Contexts))
+	for ctxName, ctx := range config.Contexts {
+		if meta, err := GetContextMetadata(ctx); err == nil && meta.Parent != "" {
+			parents[ctxName] = meta.Parent
+		}
+	}
+
+	descendants := make(map[string]bool)
+	for ctxName := range parents {
+		seen := map[string]bool{ctxName: true}
+		for p := parents[ctxName]; p != ""; p = parents[p] {
+			if p == name {
+				descendants[ctxName] = true
+				break
+			}
+			if seen[p] {
+				break
+			}
+			seen[p] = true
+		}
+	}
+	return descendants
+}
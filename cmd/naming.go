@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// NameData is the data available to --name-template. Merged contexts set the source fields,
+// scanned contexts set the sub-cluster fields.
+type NameData struct {
+	Name       string // name kontext would use without a template
+	File       string // merged file name without its extension
+	Prefix     string // --name prefix of a merge
+	Context    string // source context name
+	Cluster    string // source cluster name
+	User       string // source user name
+	Host       string // host of the context's server
+	Parent     string // parent context name of a scanned context
+	SubCluster string // sub-cluster name on the parent platform
+	Scanner    string // scan type that discovered the context
+}
+
+// NameTemplate renders context names from NameData.
+type NameTemplate struct {
+	text string
+	tmpl *template.Template
+}
+
+// ParseNameTemplate compiles a context name template, rejecting references to unknown fields.
+func ParseNameTemplate(text string) (*NameTemplate, error) {
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, NameData{}); err != nil {
+		return nil, fmt.Errorf("invalid name template: %w", err)
+	}
+	return &NameTemplate{text: text, tmpl: tmpl}, nil
+}
+
+// LoadNameTemplate parses the given name template, falling back to the nameTemplate default of
+// the kontext config file. It returns nil when neither is set.
+func LoadNameTemplate(text string) (*NameTemplate, error) {
+	if text == "" {
+		config, _, err := LoadKontextConfig()
+		if err != nil {
+			return nil, err
+		}
+		text = config.NameTemplate
+	}
+	if text == "" {
+		return nil, nil
+	}
+	return ParseNameTemplate(text)
+}
+
+// String returns the template text.
+func (t *NameTemplate) String() string {
+	if t == nil {
+		return ""
+	}
+	return t.text
+}
+
+// contextName renders a context name with the template, or uses data.Name when there is none,
+// and sanitizes the result.
+func contextName(t *NameTemplate, data NameData) (string, error) {
+	name := data.Name
+	if t != nil {
+		var buf bytes.Buffer
+		if err := t.tmpl.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("failed to render name template: %w", err)
+		}
+		name = buf.String()
+	}
+	sanitized := SanitizeName(name)
+	if sanitized == "" {
+		return "", fmt.Errorf("context name %q is empty after sanitizing", name)
+	}
+	return sanitized, nil
+}
+
+// SanitizeName makes a context name safe to use unquoted in shells and kubectl arguments.
+// Letters, digits and ._-:@ are kept; runs of other characters become a single dash, and
+// leading or trailing dashes and dots are trimmed.
+func SanitizeName(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range name {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("._-:@", r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.Trim(b.String(), "-.")
+}
+
+// serverHost returns the host of a server address, or the address itself when it cannot be parsed.
+func serverHost(server string) string {
+	if u, err := url.Parse(server); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return server
+}
@@ -4,156 +4,675 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"path"
+	"net/url"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
 )
 
-// Scan scans for sub-clusters based on the specified cluster type and returns a list of context configurations.
-// It delegates to type-specific scan functions (e.g., ScanAlauda).
-func Scan(name, server, token, clusterType string) ([]ContextConfig, error) {
-	const op = "kubeconfig.Scan"
+// Scanner discovers sub-clusters reachable through a parent context.
+// Implementations register themselves with RegisterScanner so that they become
+// available to the --scan flag and its shell completion.
+type Scanner interface {
+	// Name returns the scan type accepted by --scan (e.g., "alauda").
+	Name() string
+	// Detect reports whether the parent server looks like this platform.
+	// The context is canceled when the probe times out.
+	Detect(ctx context.Context, parent ContextConfig) (bool, error)
+	// Scan returns context configurations for the sub-clusters of the parent.
+	// The context is canceled when the scan times out; API requests should use it.
+	Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error)
+}
 
-	// Validating input parameters
+// ScanOptions tunes scanners; zero values select each scanner's defaults.
+type ScanOptions struct {
+	Namespace     string        // Namespace to read, for scanners that work within one namespace (e.g., argocd)
+	URLTemplate   string        // Go template for sub-cluster servers, for scanners that cannot derive one (e.g., vcluster)
+	Expiration    time.Duration // Lifetime of issued credentials, for scanners that request them (e.g., gardener)
+	Depth         int           // Number of levels to scan; discovered contexts are scanned again with auto detection
+	Timeout       time.Duration // Time limit of each detection probe and platform scan, including its API requests
+	Parallel      int           // Number of parent contexts scanned concurrently
+	Include       []ScanFilter  // Sub-clusters to keep; all are kept when empty
+	Exclude       []ScanFilter  // Sub-clusters to drop, applied after Include
+	Interactive   bool          // Ask which discovered sub-clusters to add before writing the kubeconfig
+	SkipUnhealthy bool          // Drop sub-clusters that carry a warning (e.g., not Running) instead of adding them
+	NameTemplate  *NameTemplate // Template for the names of discovered contexts; nil keeps each scanner's names
+	Direct        bool          // Reach sub-clusters through their own API server address, for scanners that report one (e.g., alauda)
+}
+
+// AutoScan is the scan type that detects the platform through API discovery.
+const AutoScan = "auto"
+
+// defaultScanTimeout is the time limit of a detection probe or platform scan when none is set.
+const defaultScanTimeout = 30 * time.Second
+
+// scanners holds the registered scanners keyed by scan type.
+var scanners = map[string]Scanner{}
+
+// RegisterScanner makes a scanner available under its name.
+// It panics if the name is empty or already registered.
+func RegisterScanner(s Scanner) {
+	name := s.Name()
 	if name == "" {
-		return nil, fmt.Errorf("%s: context name cannot be empty", op)
+		panic("kubeconfig.RegisterScanner: scanner name cannot be empty")
 	}
-	if server == "" {
-		return nil, fmt.Errorf("%s: server address cannot be empty", op)
+	if _, exists := scanners[name]; exists {
+		panic(fmt.Sprintf("kubeconfig.RegisterScanner: scanner %q already registered", name))
 	}
-	if token == "" {
-		return nil, fmt.Errorf("%s: token cannot be empty", op)
+	scanners[name] = s
+}
+
+// GetScanner returns the scanner registered for the given scan type,
+// falling back to a kontext-scan-<type> plugin executable on $PATH.
+func GetScanner(clusterType string) (Scanner, bool) {
+	registerConfiguredScanners()
+	if s, ok := scanners[clusterType]; ok {
+		return s, true
 	}
+	return lookupPlugin(clusterType)
+}
 
-	// Dispatching to type-specific scan function
-	switch clusterType {
-	case "alauda":
-		return ScanAlauda(name, server, token)
-	default:
-		fmt.Printf("\033[33m[%s] Skipped: unsupported clusterType %q\033[0m\n", op, clusterType)
-		return nil, nil
+// ScanTypes returns the names of all registered scanners and available plugins in sorted order.
+func ScanTypes() []string {
+	registerConfiguredScanners()
+	var types []string
+	for name := range scanners {
+		types = append(types, name)
+	}
+	for _, name := range pluginTypes() {
+		if _, exists := scanners[name]; !exists {
+			types = append(types, name)
+		}
 	}
+	sort.Strings(types)
+	return types
 }
 
-// ScanAlauda scans for clusters.platform.tkestack.io resources, constructs new context names,
-// and modifies the server URL by replacing the last path segment with the cluster name.
-func ScanAlauda(name, server, token string) ([]ContextConfig, error) {
-	const op = "kubeconfig.ScanAlauda"
+// ScanFailure records a scan that failed for a parent context.
+type ScanFailure struct {
+	Context string
+	Type    string
+	Err     error
+}
 
-	// Configuring REST client for API access
-	restConfig := &rest.Config{
-		Host:            server,
-		BearerToken:     token,
-		TLSClientConfig: rest.TLSClientConfig{Insecure: true}, // Note: Consider making TLS verification configurable
-		Timeout:         5 * time.Second,
+// ScanResult holds the outcome of scanning a single parent context.
+// Children holds the results of scanning the discovered contexts when scanning recursively.
+type ScanResult struct {
+	Parent   string
+	Types    []string
+	Contexts []ContextConfig
+	Failures []ScanFailure
+	Children []ScanResult
+	Cycle    bool     // The parent server was already scanned higher up in the tree
+	Messages []string // Messages of scans run by ScanContexts, printed in the order of the parents
+}
+
+// AllContexts returns the contexts discovered at every level of the result tree.
+func (r ScanResult) AllContexts() []ContextConfig {
+	configs := append([]ContextConfig(nil), r.Contexts...)
+	for _, child := range r.Children {
+		configs = append(configs, child.AllContexts()...)
 	}
+	return configs
+}
 
-	// Creating Kubernetes client
-	clientset, err := kubernetes.NewForConfig(restConfig)
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+// AllFailures returns the failed scans at every level of the result tree.
+func (r ScanResult) AllFailures() []ScanFailure {
+	failures := append([]ScanFailure(nil), r.Failures...)
+	for _, child := range r.Children {
+		failures = append(failures, child.AllFailures()...)
 	}
+	return failures
+}
 
-	// Checking if platform.tkestack.io API group exists
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// ScanContexts scans several parent contexts with up to opts.Parallel scans running at once.
+// Results are returned in the order of the parents, regardless of when each scan finishes, and the
+// messages of each scan are printed in that order once it and the scans before it have finished.
+func ScanContexts(parents []ContextConfig, clusterType string, opts ScanOptions) []ScanResult {
+	workers := opts.Parallel
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(parents) {
+		workers = len(parents)
+	}
 
-	apiGroupList, err := clientset.Discovery().ServerGroups()
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to discover API groups: %w", op, err)
+	results := make([]ScanResult, len(parents))
+	finished := make([]chan struct{}, len(parents))
+	for i := range finished {
+		finished[i] = make(chan struct{})
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				log := &scanLog{}
+				ctx := context.WithValue(context.Background(), scanLogKey{}, log)
+				results[i] = scanContext(ctx, parents[i], clusterType, opts)
+				results[i].Messages = log.lines()
+				close(finished[i])
+			}
+		}()
 	}
+	go func() {
+		for i := range parents {
+			jobs <- i
+		}
+		close(jobs)
+	}()
 
-	hasPlatformGroup := false
-	for _, group := range apiGroupList.Groups {
-		if group.Name == "platform.tkestack.io" {
-			hasPlatformGroup = true
-			break
+	// Printing the messages of each scan in the order of the parents
+	for i := range parents {
+		<-finished[i]
+		for _, msg := range results[i].Messages {
+			fmt.Print(msg)
 		}
 	}
+	wg.Wait()
+
+	return results
+}
+
+// ScanContext scans a parent context with the given scan type and collects per-scanner failures.
+// The auto type runs every scanner whose discovery probe matches the parent server. With a depth
+// above one, every discovered context is scanned again with auto detection, skipping servers that
+// were already scanned higher up in the tree.
+func ScanContext(parent ContextConfig, clusterType string, opts ScanOptions) ScanResult {
+	return scanContext(context.Background(), parent, clusterType, opts)
+}
+
+// scanContext scans a parent context under ctx, as described for ScanContext.
+func scanContext(ctx context.Context, parent ContextConfig, clusterType string, opts ScanOptions) ScanResult {
+	visited := map[string]bool{normalizeServer(parent.Server): true}
+	used := map[string]bool{parent.Name: true}
+	return scanTree(ctx, parent, clusterType, opts, 1, visited, used)
+}
+
+// scanTree scans a parent context at the given level and recurses into the discovered contexts.
+// visited holds the normalized servers of the parent and its ancestors, used the context names
+// given out so far in the whole tree, including the name of its root.
+func scanTree(ctx context.Context, parent ContextConfig, clusterType string, opts ScanOptions, level int, visited, used map[string]bool) ScanResult {
+	const op = "kubeconfig.ScanContext"
 
-	if !hasPlatformGroup {
-		fmt.Printf("\033[33m[%s] No platform.tkestack.io API group found\033[0m\n", op)
-		return nil, nil
+	result := ScanResult{Parent: parent.Name}
+
+	types := []string{clusterType}
+	if clusterType == AutoScan {
+		types, result.Failures = DetectScanners(ctx, parent, opts)
 	}
 
-	// Checking for clusters resource in platform.tkestack.io/v1
-	apiResources, err := clientset.Discovery().ServerResourcesForGroupVersion("platform.tkestack.io/v1")
-	if err != nil {
-		fmt.Printf("\033[33m[%s] Failed to discover platform.tkestack.io/v1 resources: %v\033[0m\n", op, err)
-		return nil, nil
+	for _, t := range types {
+		result.Types = append(result.Types, t)
+		configs, err := Scan(ctx, parent, t, opts)
+		if err != nil {
+			result.Failures = append(result.Failures, ScanFailure{Context: parent.Name, Type: t, Err: err})
+			continue
+		}
+		for _, cfg := range configs {
+			data := NameData{
+				Name:       cfg.Name,
+				Host:       serverHost(cfg.Server),
+				Parent:     parent.Name,
+				SubCluster: cfg.SubCluster,
+				Scanner:    t,
+			}
+			name, err := contextName(opts.NameTemplate, data)
+			if opts.NameTemplate != nil && (err != nil || used[name]) {
+				// Falling back to the default name when the template does not tell sub-clusters apart
+				scanPrintf(ctx, "\033[33m[%s] Name template gave no unique name for %s, using the default name\033[0m\n", op, cfg.Name)
+				name, err = contextName(nil, data)
+			}
+			if err != nil {
+				scanPrintf(ctx, "\033[33m[%s] Skipped %s: %v\033[0m\n", op, cfg.Name, err)
+				continue
+			}
+			used[name] = true
+			cfg.Name = name
+			cfg.Metadata.Parent = parent.Name
+			cfg.Metadata.Scanner = t
+			cfg.Metadata.SubCluster = cfg.SubCluster
+			cfg.Metadata.Labels = cfg.Labels
+			result.Contexts = append(result.Contexts, cfg)
+		}
 	}
 
-	hasClusterResource := false
-	for _, resource := range apiResources.APIResources {
-		if resource.Name == "clusters" {
-			hasClusterResource = true
-			break
+	// Applying include and exclude filters
+	kept, excluded := filterContexts(result.Contexts, opts)
+	if len(excluded) > 0 {
+		scanPrintf(ctx, "\033[33m[%s] Excluded %d of %d sub-clusters of %s by scan filters\033[0m\n", op, len(excluded), len(result.Contexts), parent.Name)
+	}
+	result.Contexts = kept
+
+	// Dropping unhealthy sub-clusters
+	if opts.SkipUnhealthy {
+		var healthy []ContextConfig
+		for _, cfg := range result.Contexts {
+			if cfg.Warning != "" {
+				scanPrintf(ctx, "\033[33m[%s] Skipped %s: %s\033[0m\n", op, cfg.Name, cfg.Warning)
+				continue
+			}
+			healthy = append(healthy, cfg)
 		}
+		result.Contexts = healthy
 	}
 
-	if !hasClusterResource {
-		fmt.Printf("\033[33m[%s] No clusters.platform.tkestack.io resources found\033[0m\n", op)
-		return nil, nil
+	// Scanning discovered contexts in turn
+	if level >= opts.Depth {
+		return result
+	}
+	for _, cfg := range result.Contexts {
+		server := normalizeServer(cfg.Server)
+		if visited[server] {
+			result.Children = append(result.Children, ScanResult{Parent: cfg.Name, Cycle: true})
+			continue
+		}
+		visited[server] = true
+		result.Children = append(result.Children, scanTree(ctx, cfg, AutoScan, opts, level+1, visited, used))
+		delete(visited, server)
 	}
 
-	// Retrieving cluster resources
-	resp, err := clientset.RESTClient().Get().
-		AbsPath("/apis/platform.tkestack.io/v1/clusters").
-		DoRaw(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to list clusters.platform.tkestack.io: %w", op, err)
+	return result
+}
+
+// sameHost reports whether two server URLs point at the same host and port.
+func sameHost(a, b string) bool {
+	ua, errA := url.Parse(normalizeServer(a))
+	ub, errB := url.Parse(normalizeServer(b))
+	return errA == nil && errB == nil && ua.Host != "" && ua.Host == ub.Host
+}
+
+// normalizeServer returns a server URL in a form suitable for comparing servers.
+func normalizeServer(server string) string {
+	u, err := url.Parse(server)
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(server, "/")
+	}
+	host := strings.ToLower(u.Host)
+	if u.Port() == "" && strings.EqualFold(u.Scheme, "https") {
+		host += ":443"
+	}
+	return strings.ToLower(u.Scheme) + "://" + host + strings.TrimSuffix(u.Path, "/")
+}
+
+// DetectScanners returns the scan types whose discovery probes match the parent server, along with
+// the probes that failed or timed out. A probe denied by the server counts as not matching.
+// Each probe is limited to opts.Timeout.
+func DetectScanners(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]string, []ScanFailure) {
+	const op = "kubeconfig.DetectScanners"
+
+	parent.timeout = scanTimeout(opts)
+	var matched []string
+	var failures []ScanFailure
+	for _, t := range ScanTypes() {
+		scanner, ok := GetScanner(t)
+		if !ok {
+			continue
+		}
+		var detected bool
+		err := withTimeout(ctx, parent.timeout, func(ctx context.Context) (err error) {
+			detected, err = scanner.Detect(ctx, parent)
+			return err
+		})
+		if apierrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			failures = append(failures, ScanFailure{Context: parent.Name, Type: t, Err: fmt.Errorf("%s: detection failed: %w", op, err)})
+			continue
+		}
+		if detected {
+			matched = append(matched, t)
+		}
 	}
+	return matched, failures
+}
 
-	// Parsing cluster list response
-	var clusterList struct {
-		Items []struct {
-			Metadata struct {
-				Name string `json:"name"`
-			} `json:"metadata"`
-		} `json:"items"`
+// printScanTypes prints the scanners chosen for each parent context as part of a command summary.
+func printScanTypes(results []ScanResult) {
+	fmt.Printf("  ✓ Detected platforms:\n")
+	for _, r := range results {
+		if len(r.Types) == 0 {
+			fmt.Printf("    - %s: none\n", r.Parent)
+			continue
+		}
+		fmt.Printf("    - %s: %s\n", r.Parent, strings.Join(r.Types, ", "))
 	}
-	if err := json.Unmarshal(resp, &clusterList); err != nil {
-		return nil, fmt.Errorf("%s: failed to parse clusters.platform.tkestack.io response: %w", op, err)
+}
+
+// printScanTree prints the contexts discovered at each level of recursive scans as part of a command summary.
+func printScanTree(results []ScanResult) {
+	fmt.Printf("  ✓ Scan tree:\n")
+	for _, r := range results {
+		fmt.Printf("    %s%s\n", r.Parent, scanTreeLabel(r))
+		printScanTreeChildren(r, "    ")
 	}
+}
 
-	// Constructing context configurations
-	var configs []ContextConfig
-	for _, item := range clusterList.Items {
-		clusterName := item.Metadata.Name
-		if strings.ToLower(clusterName) == "global" {
+// printScanTreeChildren prints the contexts discovered for a result below the given indent.
+func printScanTreeChildren(r ScanResult, indent string) {
+	children := make(map[string]ScanResult, len(r.Children))
+	for _, child := range r.Children {
+		children[child.Parent] = child
+	}
+	for i, cfg := range r.Contexts {
+		branch, next := "├─ ", "│  "
+		if i == len(r.Contexts)-1 {
+			branch, next = "└─ ", "   "
+		}
+		child, scanned := children[cfg.Name]
+		if !scanned {
+			fmt.Printf("%s%s%s\n", indent, branch, cfg.Name)
 			continue
 		}
+		fmt.Printf("%s%s%s%s\n", indent, branch, cfg.Name, scanTreeLabel(child))
+		printScanTreeChildren(child, indent+next)
+	}
+}
 
-		// Generating new context name
-		newContextName := fmt.Sprintf("%s-%s", name, clusterName)
+// scanTreeLabel describes how a node of the scan tree was scanned.
+func scanTreeLabel(r ScanResult) string {
+	if r.Cycle {
+		return " (cycle, not scanned)"
+	}
+	if len(r.Types) == 0 {
+		return " [none]"
+	}
+	return fmt.Sprintf(" [%s]", strings.Join(r.Types, ", "))
+}
 
-		// Extracting protocol and base path
-		protocol := "https://"
-		serverPath := server
-		if strings.HasPrefix(server, "https://") {
-			serverPath = strings.TrimPrefix(server, "https://")
-		} else if strings.HasPrefix(server, "http://") {
-			protocol = "http://"
-			serverPath = strings.TrimPrefix(server, "http://")
+// printContextWarnings prints the contexts that carry a warning as part of a command summary.
+func printContextWarnings(configs []ContextConfig) {
+	var warned []ContextConfig
+	for _, cfg := range configs {
+		if cfg.Warning != "" {
+			warned = append(warned, cfg)
 		}
+	}
+	if len(warned) == 0 {
+		return
+	}
+	fmt.Printf("  ⚠ Warnings: %d\n", len(warned))
+	for _, cfg := range warned {
+		fmt.Printf("    - %s: %s\n", cfg.Name, cfg.Warning)
+	}
+}
 
-		// Constructing new server URL by replacing the last path segment
-		newServerPath := path.Join(path.Dir(serverPath), clusterName)
-		newServer := protocol + strings.TrimLeft(newServerPath, "/")
+// printScanFailures prints failed scans as part of a command summary.
+func printScanFailures(failures []ScanFailure) {
+	fmt.Printf("  ✗ Failed scans: %d\n", len(failures))
+	for _, f := range failures {
+		fmt.Printf("    - %s (%s): %v\n", f.Context, f.Type, f.Err)
+	}
+}
 
-		configs = append(configs, ContextConfig{
-			Name:   newContextName,
-			Server: newServer,
-			Token:  token,
-		})
+// Scan scans for sub-clusters based on the specified cluster type and returns a list of context configurations.
+// It delegates to the scanner registered for the type (e.g., alauda).
+func Scan(ctx context.Context, parent ContextConfig, clusterType string, opts ScanOptions) ([]ContextConfig, error) {
+	const op = "kubeconfig.Scan"
+
+	// Validating input parameters
+	if parent.Name == "" {
+		return nil, fmt.Errorf("%s: context name cannot be empty", op)
+	}
+	if parent.Server == "" {
+		return nil, fmt.Errorf("%s: server address cannot be empty", op)
+	}
+	if parent.Token == "" && parent.AuthInfo == nil {
+		return nil, fmt.Errorf("%s: token cannot be empty", op)
 	}
 
-	if len(configs) == 0 {
-		return nil, nil
+	// Dispatching to the registered scanner
+	scanner, ok := GetScanner(clusterType)
+	if !ok {
+		return nil, fmt.Errorf("%s: unsupported clusterType %q", op, clusterType)
 	}
 
+	// Running the scan within the time limit
+	parent.timeout = scanTimeout(opts)
+	var configs []ContextConfig
+	err := withTimeout(ctx, parent.timeout, func(ctx context.Context) (err error) {
+		configs, err = scanner.Scan(ctx, parent, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 	return configs, nil
 }
+
+// scanTimeout returns the time limit of a single detection probe or platform scan.
+func scanTimeout(opts ScanOptions) time.Duration {
+	if opts.Timeout > 0 {
+		return opts.Timeout
+	}
+	return defaultScanTimeout
+}
+
+// requestTimeout returns the timeout of API requests made while scanning through the context.
+func (c ContextConfig) requestTimeout() time.Duration {
+	if c.timeout > 0 {
+		return c.timeout
+	}
+	return defaultScanTimeout
+}
+
+// withTimeout runs fn and returns its error, or a timeout error if fn does not finish in time.
+// The context passed to fn is canceled on return, so that the API requests of an abandoned fn
+// fail right away and its messages are dropped (see scanPrintf).
+func withTimeout(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+}
+
+// scanLogKey is the context key of the scanLog that collects the messages of a scan.
+type scanLogKey struct{}
+
+// scanLog collects the messages of a scan, so that concurrent scans print them in a stable order.
+type scanLog struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+// lines returns the messages collected so far.
+func (l *scanLog) lines() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.messages...)
+}
+
+// scanPrintf prints a message of the scan running under ctx, or collects it when ctx carries a
+// scanLog. Messages of scans abandoned because they timed out are dropped.
+func scanPrintf(ctx context.Context, format string, args ...interface{}) {
+	if ctx.Err() != nil {
+		return
+	}
+	log, ok := ctx.Value(scanLogKey{}).(*scanLog)
+	if !ok {
+		fmt.Printf(format, args...)
+		return
+	}
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	log.messages = append(log.messages, fmt.Sprintf(format, args...))
+}
+
+// newScanClient creates a Kubernetes client for the parent context of a scan.
+// Parents discovered by an earlier scan carry their own cluster and user entries, which are used as is.
+func newScanClient(parent ContextConfig) (*kubernetes.Clientset, error) {
+	if parent.AuthInfo != nil {
+		cluster := api.NewCluster()
+		if parent.Cluster != nil {
+			cluster = parent.Cluster.DeepCopy()
+		} else {
+			cluster.InsecureSkipTLSVerify = true
+		}
+		cluster.Server = parent.Server
+		restConfig, err := restConfigFor(cluster, parent.AuthInfo)
+		if err != nil {
+			return nil, err
+		}
+		restConfig.Timeout = parent.requestTimeout()
+		return kubernetes.NewForConfig(restConfig)
+	}
+
+	restConfig := &rest.Config{
+		Host:            parent.Server,
+		BearerToken:     parent.Token,
+		TLSClientConfig: rest.TLSClientConfig{Insecure: true}, // Note: Consider making TLS verification configurable
+		Timeout:         parent.requestTimeout(),
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// subContext returns a context for a sub-cluster reached through the parent server,
+// carrying the parent's credentials and cluster settings (e.g., certificate authority,
+// tls-server-name, proxy-url) with only the server replaced. The cluster settings are only
+// kept when the server is on the parent's host; other servers are not verified, as with
+// --scan-direct.
+func subContext(parent ContextConfig, name, server string) ContextConfig {
+	cfg := ContextConfig{Name: name, Server: server, Token: parent.Token}
+	if parent.Cluster != nil && sameHost(parent.Server, server) {
+		cfg.Cluster = parent.Cluster.DeepCopy()
+		cfg.Cluster.Server = server
+	}
+	if parent.AuthInfo != nil {
+		cfg.AuthInfo = parent.AuthInfo.DeepCopy()
+	}
+	return cfg
+}
+
+// getJSON retrieves the given API path from the parent server and decodes the JSON response into out.
+// The request is canceled with ctx and bounded by the timeout of the client created with newScanClient.
+func getJSON(ctx context.Context, clientset *kubernetes.Clientset, absPath string, out interface{}) error {
+	resp, err := clientset.RESTClient().Get().AbsPath(absPath).DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", absPath, err)
+	}
+	if err := json.Unmarshal(resp, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", absPath, err)
+	}
+	return nil
+}
+
+// getSecretData reads a single data key of a Secret through the parent server.
+func getSecretData(ctx context.Context, clientset *kubernetes.Clientset, namespace, name, key string) ([]byte, error) {
+	var secret struct {
+		Data map[string][]byte `json:"data"`
+	}
+	if err := getJSON(ctx, clientset, fmt.Sprintf("/api/v1/namespaces/%s/secrets/%s", namespace, name), &secret); err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[key]
+	if !ok || len(data) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %q key", namespace, name, key)
+	}
+	return data, nil
+}
+
+// contextFromKubeconfig builds a context configuration named name from the current
+// (or only) context of a serialized kubeconfig, carrying its full cluster and user entries.
+func contextFromKubeconfig(name string, data []byte) (ContextConfig, error) {
+	kubeconfig, err := clientcmd.Load(data)
+	if err != nil {
+		return ContextConfig{}, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	// Selecting the context to import
+	ctxName := kubeconfig.CurrentContext
+	if _, ok := kubeconfig.Contexts[ctxName]; !ok {
+		var names []string
+		for n := range kubeconfig.Contexts {
+			names = append(names, n)
+		}
+		if len(names) == 0 {
+			return ContextConfig{}, fmt.Errorf("kubeconfig has no contexts")
+		}
+		sort.Strings(names)
+		ctxName = names[0]
+	}
+	ctx := kubeconfig.Contexts[ctxName]
+
+	// Resolving cluster and user entries
+	cluster, ok := kubeconfig.Clusters[ctx.Cluster]
+	if !ok {
+		return ContextConfig{}, fmt.Errorf("kubeconfig context %q references missing cluster %q", ctxName, ctx.Cluster)
+	}
+	authInfo, ok := kubeconfig.AuthInfos[ctx.AuthInfo]
+	if !ok {
+		return ContextConfig{}, fmt.Errorf("kubeconfig context %q references missing user %q", ctxName, ctx.AuthInfo)
+	}
+
+	return ContextConfig{
+		Name:     name,
+		Server:   cluster.Server,
+		Token:    authInfo.Token,
+		Cluster:  cluster,
+		AuthInfo: authInfo,
+	}, nil
+}
+
+// serverBase returns the scheme and host of a server address (e.g., https://example.com).
+func serverBase(server string) (string, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return "", fmt.Errorf("invalid server address %s: %w", server, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid server address %s: missing scheme or host", server)
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
+
+// hasAPIGroup reports whether the server exposes the given API group.
+func hasAPIGroup(clientset *kubernetes.Clientset, group string) (bool, error) {
+	apiGroupList, err := clientset.Discovery().ServerGroups()
+	if err != nil {
+		return false, fmt.Errorf("failed to discover API groups: %w", err)
+	}
+	for _, g := range apiGroupList.Groups {
+		if g.Name == group {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hasAPIResource reports whether the server serves the named resource in the given group version.
+// An unknown group version is reported as absent rather than as an error.
+func hasAPIResource(clientset *kubernetes.Clientset, groupVersion, resource string) (bool, error) {
+	apiResources, err := clientset.Discovery().ServerResourcesForGroupVersion(groupVersion)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to discover %s resources: %w", groupVersion, err)
+	}
+	for _, r := range apiResources.APIResources {
+		if r.Name == resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
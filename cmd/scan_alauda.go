@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+func init() {
+	RegisterScanner(alaudaScanner{})
+}
+
+// alaudaURLData is the data available to --scan-url-template for Alauda servers.
+type alaudaURLData struct {
+	Scheme string // scheme of the global server
+	Host   string // host (and port) of the global server
+	Base   string // derived proxy base, e.g. https://platform.example.com/kubernetes
+	Name   string // cluster name
+	Parent string // parent context name
+}
+
+// alaudaAddress is an API server address reported in a cluster's status.addresses.
+type alaudaAddress struct {
+	Type string `json:"type"`
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+	Path string `json:"path"`
+}
+
+// alaudaAddressTypes lists the status.addresses types used for direct API endpoints, in order of preference.
+var alaudaAddressTypes = []string{"Advertise", "Real"}
+
+// alaudaScanner discovers business clusters managed by an Alauda (TKEStack) global cluster.
+type alaudaScanner struct{}
+
+func (alaudaScanner) Name() string { return "alauda" }
+
+// Detect checks for the clusters resource in platform.tkestack.io/v1.
+func (alaudaScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	if ok, err := hasAPIGroup(clientset, "platform.tkestack.io"); err != nil || !ok {
+		return false, err
+	}
+	return hasAPIResource(clientset, "platform.tkestack.io/v1", "clusters")
+}
+
+func (alaudaScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	return ScanAlauda(ctx, parent, opts.URLTemplate, opts.Direct)
+}
+
+// alaudaDisplayNameAnnotation holds the cluster name shown in the Alauda platform UI.
+const alaudaDisplayNameAnnotation = "cpaas.io/display-name"
+
+// ScanAlauda scans for clusters.platform.tkestack.io resources, constructs new context names,
+// and routes each cluster through the platform proxy at <prefix>/kubernetes/<cluster>, with the
+// prefix derived from the global server by alaudaProxyBase, or renders urlTemplate when it is set.
+// With direct, clusters are reached through the API server address in their status.addresses,
+// falling back to the proxy when none is reported. The display name, Kubernetes version and
+// phase of each cluster are recorded in the context metadata, and clusters that are not Running
+// are flagged with a warning.
+func ScanAlauda(ctx context.Context, parent ContextConfig, urlTemplate string, direct bool) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanAlauda"
+
+	name, server := parent.Name, parent.Server
+
+	// Deriving the platform proxy base
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid server address %s: %w", op, server, err)
+	}
+	base, err := alaudaProxyBase(server)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Compiling the server URL template
+	var tmpl *template.Template
+	if urlTemplate != "" {
+		tmpl, err = template.New("alauda").Option("missingkey=error").Parse(urlTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid URL template: %w", op, err)
+		}
+	}
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Checking if platform.tkestack.io API group exists
+	ctx, cancel := context.WithTimeout(ctx, parent.requestTimeout())
+	defer cancel()
+
+	hasPlatformGroup, err := hasAPIGroup(clientset, "platform.tkestack.io")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !hasPlatformGroup {
+		scanPrintf(ctx, "\033[33m[%s] No platform.tkestack.io API group found\033[0m\n", op)
+		return nil, nil
+	}
+
+	// Checking for clusters resource in platform.tkestack.io/v1
+	hasClusterResource, err := hasAPIResource(clientset, "platform.tkestack.io/v1", "clusters")
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to discover platform.tkestack.io/v1 resources: %w", op, err)
+	}
+	if !hasClusterResource {
+		scanPrintf(ctx, "\033[33m[%s] No clusters.platform.tkestack.io resources found\033[0m\n", op)
+		return nil, nil
+	}
+
+	// Retrieving cluster resources
+	resp, err := clientset.RESTClient().Get().
+		AbsPath("/apis/platform.tkestack.io/v1/clusters").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list clusters.platform.tkestack.io: %w", op, err)
+	}
+
+	// Parsing cluster list response
+	var clusterList struct {
+		Items []struct {
+			Metadata struct {
+				Name        string            `json:"name"`
+				Labels      map[string]string `json:"labels"`
+				Annotations map[string]string `json:"annotations"`
+			} `json:"metadata"`
+			Spec struct {
+				DisplayName string `json:"displayName"`
+			} `json:"spec"`
+			Status struct {
+				Version   string          `json:"version"`
+				Phase     string          `json:"phase"`
+				Addresses []alaudaAddress `json:"addresses"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(resp, &clusterList); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse clusters.platform.tkestack.io response: %w", op, err)
+	}
+
+	// Constructing context configurations
+	var configs []ContextConfig
+	for _, item := range clusterList.Items {
+		clusterName := item.Metadata.Name
+		if strings.ToLower(clusterName) == "global" {
+			continue
+		}
+
+		// Generating new context name
+		newContextName := fmt.Sprintf("%s-%s", name, clusterName)
+
+		// Constructing new server URL through the platform proxy
+		newServer := base + "/" + clusterName
+		if tmpl != nil {
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, alaudaURLData{
+				Scheme: serverURL.Scheme,
+				Host:   serverURL.Host,
+				Base:   base,
+				Name:   clusterName,
+				Parent: name,
+			}); err != nil {
+				return nil, fmt.Errorf("%s: failed to render URL template for %s: %w", op, clusterName, err)
+			}
+			newServer = buf.String()
+		}
+
+		// Using the cluster's own API server address instead
+		endpoint := ""
+		if direct {
+			if endpoint = alaudaEndpoint(item.Status.Addresses); endpoint != "" {
+				newServer = endpoint
+			} else {
+				scanPrintf(ctx, "\033[33m[%s] No API server address reported for cluster %s, using the platform proxy\033[0m\n", op, clusterName)
+			}
+		}
+
+		cfg := subContext(parent, newContextName, newServer)
+		if endpoint != "" {
+			// The platform's certificate authority and TLS settings do not apply to the cluster's own API server
+			cfg.Cluster = nil
+		}
+		cfg.SubCluster, cfg.Labels = clusterName, item.Metadata.Labels
+
+		// Recording the platform's view of the cluster
+		cfg.Metadata.DisplayName = item.Metadata.Annotations[alaudaDisplayNameAnnotation]
+		if cfg.Metadata.DisplayName == "" {
+			cfg.Metadata.DisplayName = item.Spec.DisplayName
+		}
+		cfg.Metadata.Version = item.Status.Version
+		cfg.Metadata.Phase = item.Status.Phase
+		if item.Status.Phase != "Running" {
+			phase := item.Status.Phase
+			if phase == "" {
+				phase = "unknown"
+			}
+			cfg.Warning = fmt.Sprintf("cluster is not Running (phase: %s)", phase)
+		}
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
+
+// alaudaProxyBase derives the platform proxy base that cluster names are appended to from the
+// global server address. The path up to its last /kubernetes segment is kept, so that custom
+// ingress prefixes and trailing slashes are handled. A direct API server address (without a
+// path) maps to /kubernetes on the same host and port; use --scan-url-template when the platform
+// is served elsewhere.
+// Any other path has its last segment replaced, as in <prefix>/<cluster>.
+func alaudaProxyBase(server string) (string, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return "", fmt.Errorf("invalid server address %s: %w", server, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid server address %s: missing scheme or host", server)
+	}
+
+	p := strings.TrimSuffix(u.Path, "/")
+	switch {
+	case strings.HasSuffix(p, "/kubernetes"):
+		return u.Scheme + "://" + u.Host + p, nil
+	case strings.Contains(p, "/kubernetes/"):
+		return u.Scheme + "://" + u.Host + p[:strings.LastIndex(p, "/kubernetes/")+len("/kubernetes")], nil
+	case p == "":
+		return u.Scheme + "://" + u.Host + "/kubernetes", nil
+	default:
+		return u.Scheme + "://" + u.Host + strings.TrimSuffix(path.Dir(p), "/"), nil
+	}
+}
+
+// alaudaEndpoint returns the API server URL of the preferred reported address, or "" when there is none.
+func alaudaEndpoint(addresses []alaudaAddress) string {
+	for _, addrType := range alaudaAddressTypes {
+		for _, addr := range addresses {
+			if addr.Type != addrType || addr.Host == "" {
+				continue
+			}
+			host := addr.Host
+			if addr.Port > 0 {
+				host = net.JoinHostPort(addr.Host, strconv.Itoa(int(addr.Port)))
+			} else if strings.Contains(host, ":") {
+				host = "[" + host + "]"
+			}
+			return "https://" + host + strings.TrimSuffix(addr.Path, "/")
+		}
+	}
+	return ""
+}
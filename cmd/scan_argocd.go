@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// argocdDefaultNamespace is the namespace Argo CD is installed into by default.
+const argocdDefaultNamespace = "argocd"
+
+// argocdInClusterServer is the server address Argo CD uses for the cluster it runs in.
+const argocdInClusterServer = "https://kubernetes.default.svc"
+
+func init() {
+	RegisterScanner(argocdScanner{})
+}
+
+// argocdScanner imports clusters registered in Argo CD as cluster Secrets.
+type argocdScanner struct{}
+
+func (argocdScanner) Name() string { return "argocd" }
+
+// Detect checks for the applications resource in argoproj.io/v1alpha1.
+func (argocdScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	return hasAPIResource(clientset, "argoproj.io/v1alpha1", "applications")
+}
+
+func (argocdScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = argocdDefaultNamespace
+	}
+	return ScanArgoCD(ctx, parent, namespace)
+}
+
+// argocdClusterConfig mirrors the config JSON stored in an Argo CD cluster Secret.
+type argocdClusterConfig struct {
+	Username        string `json:"username"`
+	Password        string `json:"password"`
+	BearerToken     string `json:"bearerToken"`
+	TLSClientConfig struct {
+		Insecure   bool   `json:"insecure"`
+		ServerName string `json:"serverName"`
+		CAData     []byte `json:"caData"`
+		CertData   []byte `json:"certData"`
+		KeyData    []byte `json:"keyData"`
+	} `json:"tlsClientConfig"`
+	ExecProviderConfig *struct {
+		Command     string            `json:"command"`
+		Args        []string          `json:"args"`
+		Env         map[string]string `json:"env"`
+		APIVersion  string            `json:"apiVersion"`
+		InstallHint string            `json:"installHint"`
+	} `json:"execProviderConfig"`
+	AWSAuthConfig *struct {
+		ClusterName string `json:"clusterName"`
+	} `json:"awsAuthConfig"`
+}
+
+// ScanArgoCD reads the Secrets labelled argocd.argoproj.io/secret-type=cluster in the given namespace
+// and creates one context per registered cluster with the credentials Argo CD uses for it.
+func ScanArgoCD(ctx context.Context, parent ContextConfig, namespace string) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanArgoCD"
+
+	name, server := parent.Name, parent.Server
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Retrieving cluster Secrets
+	ctx, cancel := context.WithTimeout(ctx, parent.requestTimeout())
+	defer cancel()
+
+	secrets, err := clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "argocd.argoproj.io/secret-type=cluster",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list cluster secrets in namespace %s: %w", op, namespace, err)
+	}
+	if len(secrets.Items) == 0 {
+		scanPrintf(ctx, "\033[33m[%s] No Argo CD cluster secrets found in namespace %s\033[0m\n", op, namespace)
+		return nil, nil
+	}
+
+	// Constructing context configurations
+	var configs []ContextConfig
+	for _, secret := range secrets.Items {
+		clusterServer := string(secret.Data["server"])
+		if clusterServer == "" {
+			scanPrintf(ctx, "\033[33m[%s] Skipped secret %s: no server\033[0m\n", op, secret.Name)
+			continue
+		}
+		if clusterServer == argocdInClusterServer {
+			scanPrintf(ctx, "\033[33m[%s] Skipped secret %s: in-cluster server %s\033[0m\n", op, secret.Name, clusterServer)
+			continue
+		}
+
+		// Falling back to the server host when the cluster has no name
+		clusterName := string(secret.Data["name"])
+		if clusterName == "" {
+			if u, err := url.Parse(clusterServer); err == nil && u.Host != "" {
+				clusterName = u.Hostname()
+			} else {
+				clusterName = secret.Name
+			}
+		}
+
+		var clusterConfig argocdClusterConfig
+		if raw := secret.Data["config"]; len(raw) > 0 {
+			if err := json.Unmarshal(raw, &clusterConfig); err != nil {
+				scanPrintf(ctx, "\033[33m[%s] Skipped secret %s: invalid config: %v\033[0m\n", op, secret.Name, err)
+				continue
+			}
+		}
+		if clusterConfig.AWSAuthConfig != nil {
+			scanPrintf(ctx, "\033[33m[%s] Skipped secret %s: awsAuthConfig is not supported\033[0m\n", op, secret.Name)
+			continue
+		}
+
+		// Building cluster entry
+		cluster := api.NewCluster()
+		cluster.Server = clusterServer
+		cluster.InsecureSkipTLSVerify = clusterConfig.TLSClientConfig.Insecure
+		cluster.TLSServerName = clusterConfig.TLSClientConfig.ServerName
+		cluster.CertificateAuthorityData = clusterConfig.TLSClientConfig.CAData
+
+		// Building user entry
+		authInfo := api.NewAuthInfo()
+		authInfo.Token = clusterConfig.BearerToken
+		authInfo.Username = clusterConfig.Username
+		authInfo.Password = clusterConfig.Password
+		authInfo.ClientCertificateData = clusterConfig.TLSClientConfig.CertData
+		authInfo.ClientKeyData = clusterConfig.TLSClientConfig.KeyData
+		if exec := clusterConfig.ExecProviderConfig; exec != nil {
+			authInfo.Exec = &api.ExecConfig{
+				Command:         exec.Command,
+				Args:            exec.Args,
+				APIVersion:      exec.APIVersion,
+				InstallHint:     exec.InstallHint,
+				InteractiveMode: api.NeverExecInteractiveMode,
+			}
+			var envNames []string
+			for k := range exec.Env {
+				envNames = append(envNames, k)
+			}
+			sort.Strings(envNames)
+			for _, k := range envNames {
+				authInfo.Exec.Env = append(authInfo.Exec.Env, api.ExecEnvVar{Name: k, Value: exec.Env[k]})
+			}
+		}
+
+		configs = append(configs, ContextConfig{
+			Name:       fmt.Sprintf("%s-%s", name, clusterName),
+			Server:     clusterServer,
+			Token:      clusterConfig.BearerToken,
+			Cluster:    cluster,
+			AuthInfo:   authInfo,
+			SubCluster: clusterName,
+			Labels:     secret.Labels,
+		})
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
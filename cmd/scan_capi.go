@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+)
+
+func init() {
+	RegisterScanner(capiScanner{})
+}
+
+// capiScanner imports workload clusters of a Cluster API management cluster.
+type capiScanner struct{}
+
+func (capiScanner) Name() string { return "capi" }
+
+// Detect checks for the clusters resource in cluster.x-k8s.io/v1beta1.
+func (capiScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	return hasAPIResource(clientset, "cluster.x-k8s.io/v1beta1", "clusters")
+}
+
+func (capiScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	return ScanCAPI(ctx, parent)
+}
+
+// ScanCAPI scans for clusters.cluster.x-k8s.io resources across namespaces and imports the admin
+// kubeconfig stored in each cluster's <cluster>-kubeconfig Secret with its full credentials.
+func ScanCAPI(ctx context.Context, parent ContextConfig) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanCAPI"
+
+	name, server := parent.Name, parent.Server
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Checking for clusters resource in cluster.x-k8s.io/v1beta1
+	hasClusterResource, err := hasAPIResource(clientset, "cluster.x-k8s.io/v1beta1", "clusters")
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to discover cluster.x-k8s.io/v1beta1 resources: %w", op, err)
+	}
+	if !hasClusterResource {
+		scanPrintf(ctx, "\033[33m[%s] No clusters.cluster.x-k8s.io resources found\033[0m\n", op)
+		return nil, nil
+	}
+
+	// Retrieving workload clusters across namespaces
+	var clusterList struct {
+		Items []struct {
+			Metadata struct {
+				Name      string            `json:"name"`
+				Namespace string            `json:"namespace"`
+				Labels    map[string]string `json:"labels"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := getJSON(ctx, clientset, "/apis/cluster.x-k8s.io/v1beta1/clusters", &clusterList); err != nil {
+		return nil, fmt.Errorf("%s: failed to list clusters.cluster.x-k8s.io: %w", op, err)
+	}
+
+	// Counting cluster names to qualify duplicates with their namespace
+	nameCount := make(map[string]int)
+	for _, item := range clusterList.Items {
+		nameCount[item.Metadata.Name]++
+	}
+
+	// Importing workload cluster kubeconfigs
+	var configs []ContextConfig
+	for _, item := range clusterList.Items {
+		clusterName := item.Metadata.Name
+		namespace := item.Metadata.Namespace
+
+		data, err := getSecretData(ctx, clientset, namespace, clusterName+"-kubeconfig", "value")
+		if err != nil {
+			scanPrintf(ctx, "\033[33m[%s] Skipped cluster %s/%s: %v\033[0m\n", op, namespace, clusterName, err)
+			continue
+		}
+
+		newContextName := fmt.Sprintf("%s-%s", name, clusterName)
+		if nameCount[clusterName] > 1 {
+			newContextName = fmt.Sprintf("%s-%s-%s", name, namespace, clusterName)
+		}
+
+		cfg, err := contextFromKubeconfig(newContextName, data)
+		if err != nil {
+			scanPrintf(ctx, "\033[33m[%s] Skipped cluster %s/%s: %v\033[0m\n", op, namespace, clusterName, err)
+			continue
+		}
+		cfg.SubCluster, cfg.Labels = clusterName, item.Metadata.Labels
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
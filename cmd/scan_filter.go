@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// scanFilterRegexpPrefix marks a filter value as a regular expression instead of a glob.
+const scanFilterRegexpPrefix = "re:"
+
+// ScanFilter matches discovered sub-clusters by name or by label.
+type ScanFilter struct {
+	pattern string         // Pattern as given on the command line
+	label   string         // Label key to match, empty to match the sub-cluster name
+	glob    string         // Glob matched against the whole value
+	re      *regexp.Regexp // Regular expression matched anywhere in the value, set instead of glob
+}
+
+// ParseScanFilter parses a --scan-include or --scan-exclude pattern.
+// A pattern of the form key=value matches the value of a label, any other pattern matches the
+// sub-cluster name. Values are globs (e.g., prod-*), or regular expressions when prefixed with re:.
+func ParseScanFilter(pattern string) (ScanFilter, error) {
+	f := ScanFilter{pattern: pattern}
+	value := pattern
+	if !strings.HasPrefix(pattern, scanFilterRegexpPrefix) {
+		if key, v, ok := strings.Cut(pattern, "="); ok {
+			if key == "" {
+				return f, fmt.Errorf("invalid scan filter %q: empty label key", pattern)
+			}
+			f.label, value = key, v
+		}
+	}
+
+	if strings.HasPrefix(value, scanFilterRegexpPrefix) {
+		re, err := regexp.Compile(strings.TrimPrefix(value, scanFilterRegexpPrefix))
+		if err != nil {
+			return f, fmt.Errorf("invalid scan filter %q: %w", pattern, err)
+		}
+		f.re = re
+		return f, nil
+	}
+	if _, err := path.Match(value, ""); err != nil {
+		return f, fmt.Errorf("invalid scan filter %q: %w", pattern, err)
+	}
+	f.glob = value
+	return f, nil
+}
+
+// String returns the pattern the filter was parsed from.
+func (f ScanFilter) String() string {
+	return f.pattern
+}
+
+// Match reports whether a discovered context matches the filter.
+// Name filters use the sub-cluster name, falling back to the context name.
+func (f ScanFilter) Match(cfg ContextConfig) bool {
+	value := cfg.SubCluster
+	if value == "" {
+		value = cfg.Name
+	}
+	if f.label != "" {
+		var ok bool
+		if value, ok = cfg.Labels[f.label]; !ok {
+			return false
+		}
+	}
+
+	if f.re != nil {
+		return f.re.MatchString(value)
+	}
+	matched, _ := path.Match(f.glob, value)
+	return matched
+}
+
+// filterContexts splits discovered contexts into those kept and those excluded by the scan filters.
+// A context is kept when it matches any include filter (or none are given) and no exclude filter.
+func filterContexts(configs []ContextConfig, opts ScanOptions) (kept, excluded []ContextConfig) {
+	for _, cfg := range configs {
+		if matchesAnyFilter(cfg, opts.Include, true) && !matchesAnyFilter(cfg, opts.Exclude, false) {
+			kept = append(kept, cfg)
+		} else {
+			excluded = append(excluded, cfg)
+		}
+	}
+	return kept, excluded
+}
+
+// matchesAnyFilter reports whether the context matches one of the filters, or empty when there are none.
+func matchesAnyFilter(cfg ContextConfig, filters []ScanFilter, empty bool) bool {
+	if len(filters) == 0 {
+		return empty
+	}
+	for _, f := range filters {
+		if f.Match(cfg) {
+			return true
+		}
+	}
+	return false
+}
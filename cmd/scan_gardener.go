@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// gardenerDefaultExpiration is the lifetime requested for shoot admin kubeconfigs by default.
+const gardenerDefaultExpiration = 24 * time.Hour
+
+func init() {
+	RegisterScanner(gardenerScanner{})
+}
+
+// gardenerScanner issues short-lived admin kubeconfigs for the shoots of a Gardener garden cluster.
+type gardenerScanner struct{}
+
+func (gardenerScanner) Name() string { return "gardener" }
+
+// Detect checks for the shoots resource in core.gardener.cloud/v1beta1.
+func (gardenerScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	return hasAPIResource(clientset, "core.gardener.cloud/v1beta1", "shoots")
+}
+
+func (gardenerScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	expiration := opts.Expiration
+	if expiration <= 0 {
+		expiration = gardenerDefaultExpiration
+	}
+	return ScanGardener(ctx, parent, expiration)
+}
+
+// ScanGardener lists the shoots in every project namespace visible to the token and requests an admin
+// kubeconfig for each through the shoots/adminkubeconfig subresource. Contexts are named
+// <parent>-<project>-<shoot> and record the kubeconfig expiry.
+func ScanGardener(ctx context.Context, parent ContextConfig, expiration time.Duration) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanGardener"
+
+	name, server := parent.Name, parent.Server
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Retrieving projects visible to the token
+	var projectList struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Spec struct {
+				Namespace string `json:"namespace"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := getJSON(ctx, clientset, "/apis/core.gardener.cloud/v1beta1/projects", &projectList); err != nil {
+		return nil, fmt.Errorf("%s: failed to list projects.core.gardener.cloud: %w", op, err)
+	}
+
+	// Building the admin kubeconfig request body
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "authentication.gardener.cloud/v1alpha1",
+		"kind":       "AdminKubeconfigRequest",
+		"spec": map[string]interface{}{
+			"expirationSeconds": int64(expiration.Seconds()),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to encode AdminKubeconfigRequest: %w", op, err)
+	}
+
+	// Constructing context configurations
+	var configs []ContextConfig
+	for _, project := range projectList.Items {
+		namespace := project.Spec.Namespace
+		if namespace == "" {
+			continue
+		}
+
+		// Retrieving shoots in the project namespace
+		var shootList struct {
+			Items []struct {
+				Metadata struct {
+					Name   string            `json:"name"`
+					Labels map[string]string `json:"labels"`
+				} `json:"metadata"`
+				Status struct {
+					IsHibernated bool `json:"hibernated"`
+				} `json:"status"`
+			} `json:"items"`
+		}
+		if err := getJSON(ctx, clientset, fmt.Sprintf("/apis/core.gardener.cloud/v1beta1/namespaces/%s/shoots", namespace), &shootList); err != nil {
+			scanPrintf(ctx, "\033[33m[%s] Skipped project %s: %v\033[0m\n", op, project.Metadata.Name, err)
+			continue
+		}
+
+		for _, shoot := range shootList.Items {
+			shootName := shoot.Metadata.Name
+
+			// Requesting a short-lived admin kubeconfig
+			reqCtx, cancel := context.WithTimeout(ctx, parent.requestTimeout())
+			resp, err := clientset.RESTClient().Post().
+				AbsPath(fmt.Sprintf("/apis/core.gardener.cloud/v1beta1/namespaces/%s/shoots/%s/adminkubeconfig", namespace, shootName)).
+				SetHeader("Content-Type", "application/json").
+				Body(body).
+				DoRaw(reqCtx)
+			cancel()
+			if err != nil {
+				scanPrintf(ctx, "\033[33m[%s] Skipped shoot %s/%s: failed to request admin kubeconfig: %v\033[0m\n", op, namespace, shootName, err)
+				continue
+			}
+
+			var request struct {
+				Status struct {
+					Kubeconfig          []byte    `json:"kubeconfig"`
+					ExpirationTimestamp time.Time `json:"expirationTimestamp"`
+				} `json:"status"`
+			}
+			if err := json.Unmarshal(resp, &request); err != nil {
+				scanPrintf(ctx, "\033[33m[%s] Skipped shoot %s/%s: failed to parse admin kubeconfig response: %v\033[0m\n", op, namespace, shootName, err)
+				continue
+			}
+
+			projectName := project.Metadata.Name
+			if projectName == "" {
+				projectName = strings.TrimPrefix(namespace, "garden-")
+			}
+			cfg, err := contextFromKubeconfig(fmt.Sprintf("%s-%s-%s", name, projectName, shootName), request.Status.Kubeconfig)
+			if err != nil {
+				scanPrintf(ctx, "\033[33m[%s] Skipped shoot %s/%s: %v\033[0m\n", op, namespace, shootName, err)
+				continue
+			}
+			if !request.Status.ExpirationTimestamp.IsZero() {
+				expiresAt := request.Status.ExpirationTimestamp
+				cfg.Metadata.ExpiresAt = &expiresAt
+			}
+			cfg.SubCluster, cfg.Labels = shootName, shoot.Metadata.Labels
+			if shoot.Status.IsHibernated {
+				cfg.Warning = "shoot is hibernated"
+			}
+
+			configs = append(configs, cfg)
+		}
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
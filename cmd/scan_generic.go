@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// GenericScannerConfig declares a CRD-based scanner in the kontext config file.
+// Each item of the listed resource becomes a sub-cluster whose server URL is rendered from URLTemplate.
+//
+// Example:
+//
+//	scanners:
+//	  - name: myplatform
+//	    group: platform.example.io
+//	    version: v1
+//	    resource: clusters
+//	    nameField: metadata.name
+//	    skip: [global]
+//	    urlTemplate: "{{.Scheme}}://{{.Host}}/kubernetes/{{.Name}}"
+type GenericScannerConfig struct {
+	Name         string   `json:"name"`
+	Group        string   `json:"group"`
+	Version      string   `json:"version"`
+	Resource     string   `json:"resource"`
+	NameField    string   `json:"nameField,omitempty"`
+	Skip         []string `json:"skip,omitempty"`
+	URLTemplate  string   `json:"urlTemplate"`
+	InheritToken *bool    `json:"inheritToken,omitempty"`
+	TokenField   string   `json:"tokenField,omitempty"`
+}
+
+// genericURLData is the data available to a generic scanner's URL template.
+type genericURLData struct {
+	Scheme string // scheme of the parent server
+	Host   string // host (and port) of the parent server
+	Path   string // path of the parent server, without trailing slash
+	Name   string // sub-cluster name
+	Parent string // parent context name
+}
+
+// genericScanner lists a configured CRD and derives sub-cluster contexts from its items.
+type genericScanner struct {
+	config GenericScannerConfig
+	url    *template.Template
+}
+
+var configuredScannersOnce sync.Once
+
+// registerConfiguredScanners registers the generic scanners declared in the kontext config file.
+// Invalid entries are reported and skipped so that built-in scanners keep working.
+func registerConfiguredScanners() {
+	configuredScannersOnce.Do(func() {
+		const op = "kubeconfig.registerConfiguredScanners"
+
+		config, configPath, err := LoadKontextConfig()
+		if err != nil {
+			fmt.Printf("\033[33m[%s] Skipped configured scanners: %v\033[0m\n", op, err)
+			return
+		}
+
+		for _, sc := range config.Scanners {
+			scanner, err := newGenericScanner(sc)
+			if err != nil {
+				fmt.Printf("\033[33m[%s] Skipped scanner %q in %s: %v\033[0m\n", op, sc.Name, configPath, err)
+				continue
+			}
+			if _, exists := scanners[sc.Name]; exists {
+				fmt.Printf("\033[33m[%s] Skipped scanner %q in %s: name already registered\033[0m\n", op, sc.Name, configPath)
+				continue
+			}
+			scanners[sc.Name] = scanner
+		}
+	})
+}
+
+// newGenericScanner validates a scanner declaration and compiles its URL template.
+func newGenericScanner(config GenericScannerConfig) (*genericScanner, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	if config.Name == AutoScan {
+		return nil, fmt.Errorf("name %q is reserved", AutoScan)
+	}
+	if config.Version == "" || config.Resource == "" {
+		return nil, fmt.Errorf("version and resource are required")
+	}
+	if config.URLTemplate == "" {
+		return nil, fmt.Errorf("urlTemplate is required")
+	}
+	if config.NameField == "" {
+		config.NameField = "metadata.name"
+	}
+	if config.InheritToken != nil && !*config.InheritToken && config.TokenField == "" {
+		return nil, fmt.Errorf("tokenField is required when inheritToken is false")
+	}
+
+	tmpl, err := template.New(config.Name).Option("missingkey=error").Parse(config.URLTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid urlTemplate: %w", err)
+	}
+
+	return &genericScanner{config: config, url: tmpl}, nil
+}
+
+func (g *genericScanner) Name() string { return g.config.Name }
+
+// groupVersion returns the API group version of the configured resource.
+func (g *genericScanner) groupVersion() string {
+	if g.config.Group == "" {
+		return g.config.Version
+	}
+	return g.config.Group + "/" + g.config.Version
+}
+
+// listPath returns the API path that lists the configured resource across namespaces.
+func (g *genericScanner) listPath() string {
+	if g.config.Group == "" {
+		return fmt.Sprintf("/api/%s/%s", g.config.Version, g.config.Resource)
+	}
+	return fmt.Sprintf("/apis/%s/%s/%s", g.config.Group, g.config.Version, g.config.Resource)
+}
+
+// Detect checks that the configured resource is served by the parent server.
+func (g *genericScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	return hasAPIResource(clientset, g.groupVersion(), g.config.Resource)
+}
+
+func (g *genericScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanGeneric"
+
+	// Parsing parent server address
+	serverURL, err := url.Parse(parent.Server)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid server address %s: %w", op, parent.Server, err)
+	}
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, parent.Server, err)
+	}
+
+	// Checking for the configured resource
+	hasResource, err := hasAPIResource(clientset, g.groupVersion(), g.config.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to discover %s resources: %w", op, g.groupVersion(), err)
+	}
+	if !hasResource {
+		scanPrintf(ctx, "\033[33m[%s] No %s resources found in %s\033[0m\n", op, g.config.Resource, g.groupVersion())
+		return nil, nil
+	}
+
+	// Retrieving resources
+	ctx, cancel := context.WithTimeout(ctx, parent.requestTimeout())
+	defer cancel()
+
+	resp, err := clientset.RESTClient().Get().AbsPath(g.listPath()).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list %s: %w", op, g.listPath(), err)
+	}
+
+	var list struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	if err := json.Unmarshal(resp, &list); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse %s response: %w", op, g.listPath(), err)
+	}
+
+	// Constructing context configurations
+	var configs []ContextConfig
+	for _, item := range list.Items {
+		clusterName, ok := fieldString(item, g.config.NameField)
+		if !ok || clusterName == "" {
+			scanPrintf(ctx, "\033[33m[%s] Skipped item without %s\033[0m\n", op, g.config.NameField)
+			continue
+		}
+		if g.skipped(clusterName) {
+			continue
+		}
+
+		// Rendering sub-cluster server URL
+		var newServer bytes.Buffer
+		if err := g.url.Execute(&newServer, genericURLData{
+			Scheme: serverURL.Scheme,
+			Host:   serverURL.Host,
+			Path:   strings.TrimSuffix(serverURL.Path, "/"),
+			Name:   clusterName,
+			Parent: parent.Name,
+		}); err != nil {
+			return nil, fmt.Errorf("%s: failed to render urlTemplate for %s: %w", op, clusterName, err)
+		}
+
+		cfg := subContext(parent, fmt.Sprintf("%s-%s", parent.Name, clusterName), newServer.String())
+
+		// Resolving sub-cluster token
+		if g.config.InheritToken != nil && !*g.config.InheritToken {
+			token, _ := fieldString(item, g.config.TokenField)
+			if token == "" {
+				scanPrintf(ctx, "\033[33m[%s] Skipped %s: no token at %s\033[0m\n", op, clusterName, g.config.TokenField)
+				continue
+			}
+			cfg.Token, cfg.AuthInfo = token, nil
+		}
+
+		cfg.SubCluster, cfg.Labels = clusterName, itemLabels(item)
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
+
+// skipped reports whether the sub-cluster is in the skip list (case-insensitive).
+func (g *genericScanner) skipped(clusterName string) bool {
+	for _, s := range g.config.Skip {
+		if strings.EqualFold(s, clusterName) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldString resolves a dotted field path (e.g., metadata.name) to a string value.
+func fieldString(obj map[string]interface{}, fieldPath string) (string, bool) {
+	var current interface{} = obj
+	for _, key := range strings.Split(fieldPath, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if current, ok = m[key]; !ok {
+			return "", false
+		}
+	}
+	s, ok := current.(string)
+	return s, ok
+}
+
+// itemLabels returns the metadata.labels of an unstructured item.
+func itemLabels(item map[string]interface{}) map[string]string {
+	metadata, _ := item["metadata"].(map[string]interface{})
+	raw, _ := metadata["labels"].(map[string]interface{})
+	if len(raw) == 0 {
+		return nil
+	}
+	labels := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if s, ok := v.(string); ok {
+			labels[k] = s
+		}
+	}
+	return labels
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+)
+
+func init() {
+	RegisterScanner(hypershiftScanner{})
+}
+
+// hypershiftScanner imports the admin kubeconfigs of HyperShift hosted clusters.
+type hypershiftScanner struct{}
+
+func (hypershiftScanner) Name() string { return "hypershift" }
+
+// Detect checks for the hostedclusters resource in hypershift.openshift.io/v1beta1.
+func (hypershiftScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	return hasAPIResource(clientset, "hypershift.openshift.io/v1beta1", "hostedclusters")
+}
+
+func (hypershiftScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	return ScanHyperShift(ctx, parent)
+}
+
+// ScanHyperShift scans for hostedclusters.hypershift.openshift.io resources across namespaces and imports
+// the admin kubeconfig Secret referenced by each cluster's status.kubeconfig.
+func ScanHyperShift(ctx context.Context, parent ContextConfig) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanHyperShift"
+
+	name, server := parent.Name, parent.Server
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Checking for hostedclusters resource in hypershift.openshift.io/v1beta1
+	hasClusterResource, err := hasAPIResource(clientset, "hypershift.openshift.io/v1beta1", "hostedclusters")
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to discover hypershift.openshift.io/v1beta1 resources: %w", op, err)
+	}
+	if !hasClusterResource {
+		scanPrintf(ctx, "\033[33m[%s] No hostedclusters.hypershift.openshift.io resources found\033[0m\n", op)
+		return nil, nil
+	}
+
+	// Retrieving hosted clusters across namespaces
+	var clusterList struct {
+		Items []struct {
+			Metadata struct {
+				Name      string            `json:"name"`
+				Namespace string            `json:"namespace"`
+				Labels    map[string]string `json:"labels"`
+			} `json:"metadata"`
+			Status struct {
+				Kubeconfig *struct {
+					Name string `json:"name"`
+				} `json:"kubeconfig"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := getJSON(ctx, clientset, "/apis/hypershift.openshift.io/v1beta1/hostedclusters", &clusterList); err != nil {
+		return nil, fmt.Errorf("%s: failed to list hostedclusters.hypershift.openshift.io: %w", op, err)
+	}
+
+	// Counting cluster names to qualify duplicates with their namespace
+	nameCount := make(map[string]int)
+	for _, item := range clusterList.Items {
+		nameCount[item.Metadata.Name]++
+	}
+
+	// Importing hosted cluster kubeconfigs
+	var configs []ContextConfig
+	for _, item := range clusterList.Items {
+		clusterName := item.Metadata.Name
+		namespace := item.Metadata.Namespace
+
+		if item.Status.Kubeconfig == nil || item.Status.Kubeconfig.Name == "" {
+			scanPrintf(ctx, "\033[33m[%s] Skipped hosted cluster %s/%s: kubeconfig not published yet\033[0m\n", op, namespace, clusterName)
+			continue
+		}
+		data, err := getSecretData(ctx, clientset, namespace, item.Status.Kubeconfig.Name, "kubeconfig")
+		if err != nil {
+			scanPrintf(ctx, "\033[33m[%s] Skipped hosted cluster %s/%s: %v\033[0m\n", op, namespace, clusterName, err)
+			continue
+		}
+
+		newContextName := fmt.Sprintf("%s-%s", name, clusterName)
+		if nameCount[clusterName] > 1 {
+			newContextName = fmt.Sprintf("%s-%s-%s", name, namespace, clusterName)
+		}
+
+		cfg, err := contextFromKubeconfig(newContextName, data)
+		if err != nil {
+			scanPrintf(ctx, "\033[33m[%s] Skipped hosted cluster %s/%s: %v\033[0m\n", op, namespace, clusterName, err)
+			continue
+		}
+		cfg.SubCluster, cfg.Labels = clusterName, item.Metadata.Labels
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+)
+
+func init() {
+	RegisterScanner(kamajiScanner{})
+}
+
+// kamajiScanner imports the admin kubeconfigs of Kamaji tenant control planes.
+type kamajiScanner struct{}
+
+func (kamajiScanner) Name() string { return "kamaji" }
+
+// Detect checks for the tenantcontrolplanes resource in kamaji.clastix.io/v1alpha1.
+func (kamajiScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	return hasAPIResource(clientset, "kamaji.clastix.io/v1alpha1", "tenantcontrolplanes")
+}
+
+func (kamajiScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	return ScanKamaji(ctx, parent)
+}
+
+// ScanKamaji scans for tenantcontrolplanes.kamaji.clastix.io resources across namespaces and imports
+// the admin kubeconfig Secret referenced by each control plane's status.kubeconfig.admin.
+func ScanKamaji(ctx context.Context, parent ContextConfig) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanKamaji"
+
+	name, server := parent.Name, parent.Server
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Checking for tenantcontrolplanes resource in kamaji.clastix.io/v1alpha1
+	hasTCPResource, err := hasAPIResource(clientset, "kamaji.clastix.io/v1alpha1", "tenantcontrolplanes")
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to discover kamaji.clastix.io/v1alpha1 resources: %w", op, err)
+	}
+	if !hasTCPResource {
+		scanPrintf(ctx, "\033[33m[%s] No tenantcontrolplanes.kamaji.clastix.io resources found\033[0m\n", op)
+		return nil, nil
+	}
+
+	// Retrieving tenant control planes across namespaces
+	var tcpList struct {
+		Items []struct {
+			Metadata struct {
+				Name      string            `json:"name"`
+				Namespace string            `json:"namespace"`
+				Labels    map[string]string `json:"labels"`
+			} `json:"metadata"`
+			Status struct {
+				Kubeconfig struct {
+					Admin struct {
+						SecretName string `json:"secretName"`
+					} `json:"admin"`
+				} `json:"kubeconfig"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := getJSON(ctx, clientset, "/apis/kamaji.clastix.io/v1alpha1/tenantcontrolplanes", &tcpList); err != nil {
+		return nil, fmt.Errorf("%s: failed to list tenantcontrolplanes.kamaji.clastix.io: %w", op, err)
+	}
+
+	// Counting control plane names to qualify duplicates with their namespace
+	nameCount := make(map[string]int)
+	for _, item := range tcpList.Items {
+		nameCount[item.Metadata.Name]++
+	}
+
+	// Importing tenant control plane kubeconfigs
+	var configs []ContextConfig
+	for _, item := range tcpList.Items {
+		tcpName := item.Metadata.Name
+		namespace := item.Metadata.Namespace
+
+		secretName := item.Status.Kubeconfig.Admin.SecretName
+		if secretName == "" {
+			scanPrintf(ctx, "\033[33m[%s] Skipped tenant control plane %s/%s: kubeconfig not published yet\033[0m\n", op, namespace, tcpName)
+			continue
+		}
+		data, err := getSecretData(ctx, clientset, namespace, secretName, "admin.conf")
+		if err != nil {
+			scanPrintf(ctx, "\033[33m[%s] Skipped tenant control plane %s/%s: %v\033[0m\n", op, namespace, tcpName, err)
+			continue
+		}
+
+		newContextName := fmt.Sprintf("%s-%s", name, tcpName)
+		if nameCount[tcpName] > 1 {
+			newContextName = fmt.Sprintf("%s-%s-%s", name, namespace, tcpName)
+		}
+
+		cfg, err := contextFromKubeconfig(newContextName, data)
+		if err != nil {
+			scanPrintf(ctx, "\033[33m[%s] Skipped tenant control plane %s/%s: %v\033[0m\n", op, namespace, tcpName, err)
+			continue
+		}
+		cfg.SubCluster, cfg.Labels = tcpName, item.Metadata.Labels
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
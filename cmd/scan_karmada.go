@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterScanner(karmadaScanner{})
+}
+
+// karmadaScanner discovers member clusters registered with a Karmada control plane.
+type karmadaScanner struct{}
+
+func (karmadaScanner) Name() string { return "karmada" }
+
+// Detect checks for the clusters resource in cluster.karmada.io/v1alpha1.
+func (karmadaScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	return hasAPIResource(clientset, "cluster.karmada.io/v1alpha1", "clusters")
+}
+
+func (karmadaScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	return ScanKarmada(ctx, parent)
+}
+
+// ScanKarmada scans for clusters.cluster.karmada.io resources and creates one context per member
+// cluster, reachable through the aggregated proxy of the Karmada API server. Members that are not
+// Ready are still added but carry a warning.
+func ScanKarmada(ctx context.Context, parent ContextConfig) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanKarmada"
+
+	name, server := parent.Name, parent.Server
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Checking for clusters resource in cluster.karmada.io/v1alpha1
+	hasClusterResource, err := hasAPIResource(clientset, "cluster.karmada.io/v1alpha1", "clusters")
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to discover cluster.karmada.io/v1alpha1 resources: %w", op, err)
+	}
+	if !hasClusterResource {
+		scanPrintf(ctx, "\033[33m[%s] No clusters.cluster.karmada.io resources found\033[0m\n", op)
+		return nil, nil
+	}
+
+	// Retrieving member clusters
+	var clusterList struct {
+		Items []struct {
+			Metadata struct {
+				Name   string            `json:"name"`
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+			Status struct {
+				Conditions []struct {
+					Type   string `json:"type"`
+					Status string `json:"status"`
+					Reason string `json:"reason"`
+				} `json:"conditions"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := getJSON(ctx, clientset, "/apis/cluster.karmada.io/v1alpha1/clusters", &clusterList); err != nil {
+		return nil, fmt.Errorf("%s: failed to list clusters.cluster.karmada.io: %w", op, err)
+	}
+
+	// Constructing context configurations
+	base := strings.TrimSuffix(server, "/")
+	var configs []ContextConfig
+	for _, item := range clusterList.Items {
+		clusterName := item.Metadata.Name
+
+		// Flagging members that are not Ready
+		warning := "member cluster is not Ready (no Ready condition)"
+		for _, cond := range item.Status.Conditions {
+			if cond.Type != "Ready" {
+				continue
+			}
+			if cond.Status == "True" {
+				warning = ""
+			} else {
+				warning = fmt.Sprintf("member cluster is not Ready (%s)", cond.Reason)
+			}
+			break
+		}
+
+		cfg := subContext(parent, fmt.Sprintf("%s-%s", name, clusterName), fmt.Sprintf("%s/apis/cluster.karmada.io/v1alpha1/clusters/%s/proxy", base, clusterName))
+		cfg.SubCluster, cfg.Labels = clusterName, item.Metadata.Labels
+		cfg.Warning = warning
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterScanner(kubesphereScanner{})
+}
+
+// kubesphereScanner discovers member clusters of a KubeSphere host cluster.
+type kubesphereScanner struct{}
+
+func (kubesphereScanner) Name() string { return "kubesphere" }
+
+// Detect checks for the clusters resource in cluster.kubesphere.io/v1alpha1.
+func (kubesphereScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	return hasAPIResource(clientset, "cluster.kubesphere.io/v1alpha1", "clusters")
+}
+
+func (kubesphereScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	return ScanKubeSphere(ctx, parent)
+}
+
+// ScanKubeSphere scans for clusters.cluster.kubesphere.io resources and creates one context per member
+// cluster, reachable through the KubeSphere multi-cluster proxy at <host>/kapis/clusters/<name>.
+func ScanKubeSphere(ctx context.Context, parent ContextConfig) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanKubeSphere"
+
+	name, server := parent.Name, parent.Server
+
+	// Resolving KubeSphere base address
+	base, err := serverBase(server)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Checking for clusters resource in cluster.kubesphere.io/v1alpha1
+	hasClusterResource, err := hasAPIResource(clientset, "cluster.kubesphere.io/v1alpha1", "clusters")
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to discover cluster.kubesphere.io/v1alpha1 resources: %w", op, err)
+	}
+	if !hasClusterResource {
+		scanPrintf(ctx, "\033[33m[%s] No clusters.cluster.kubesphere.io resources found\033[0m\n", op)
+		return nil, nil
+	}
+
+	// Retrieving member clusters
+	var clusterList struct {
+		Items []struct {
+			Metadata struct {
+				Name   string            `json:"name"`
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := getJSON(ctx, clientset, "/apis/cluster.kubesphere.io/v1alpha1/clusters", &clusterList); err != nil {
+		return nil, fmt.Errorf("%s: failed to list clusters.cluster.kubesphere.io: %w", op, err)
+	}
+
+	// Constructing context configurations
+	var configs []ContextConfig
+	for _, item := range clusterList.Items {
+		clusterName := item.Metadata.Name
+		if strings.ToLower(clusterName) == "host" {
+			continue
+		}
+
+		cfg := subContext(parent, fmt.Sprintf("%s-%s", name, clusterName), fmt.Sprintf("%s/kapis/clusters/%s", base, clusterName))
+		cfg.SubCluster, cfg.Labels = clusterName, item.Metadata.Labels
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"text/template"
+)
+
+// ocmDefaultURLTemplate routes through the cluster-proxy addon's user server in the hub cluster.
+const ocmDefaultURLTemplate = "https://cluster-proxy-addon-user.open-cluster-management-cluster-proxy.svc:9092/{{.Name}}"
+
+func init() {
+	RegisterScanner(ocmScanner{})
+}
+
+// ocmURLData is the data available to --scan-url-template for Open Cluster Management servers.
+type ocmURLData struct {
+	Scheme string // scheme of the hub server
+	Host   string // host (and port) of the hub server
+	Name   string // managed cluster name
+	Parent string // parent context name
+}
+
+// ocmScanner discovers managed clusters of an Open Cluster Management hub.
+type ocmScanner struct{}
+
+func (ocmScanner) Name() string { return "ocm" }
+
+// Detect checks for the managedclusters resource in cluster.open-cluster-management.io/v1.
+func (ocmScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	return hasAPIResource(clientset, "cluster.open-cluster-management.io/v1", "managedclusters")
+}
+
+func (ocmScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	return ScanOCM(ctx, parent, opts.URLTemplate)
+}
+
+// ScanOCM scans for managedclusters.cluster.open-cluster-management.io resources and creates one context
+// per available managed cluster, routed through the cluster-proxy addon's user server. The server URL is
+// rendered from urlTemplate, defaulting to the in-cluster user server Service.
+func ScanOCM(ctx context.Context, parent ContextConfig, urlTemplate string) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanOCM"
+
+	name, server := parent.Name, parent.Server
+
+	// Compiling the server URL template
+	if urlTemplate == "" {
+		urlTemplate = ocmDefaultURLTemplate
+	}
+	tmpl, err := template.New("ocm").Option("missingkey=error").Parse(urlTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid URL template: %w", op, err)
+	}
+
+	// Parsing hub server address
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid server address %s: %w", op, server, err)
+	}
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Checking for managedclusters resource in cluster.open-cluster-management.io/v1
+	hasClusterResource, err := hasAPIResource(clientset, "cluster.open-cluster-management.io/v1", "managedclusters")
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to discover cluster.open-cluster-management.io/v1 resources: %w", op, err)
+	}
+	if !hasClusterResource {
+		scanPrintf(ctx, "\033[33m[%s] No managedclusters.cluster.open-cluster-management.io resources found\033[0m\n", op)
+		return nil, nil
+	}
+
+	// Retrieving managed clusters
+	var clusterList struct {
+		Items []struct {
+			Metadata struct {
+				Name   string            `json:"name"`
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+			Status struct {
+				Conditions []struct {
+					Type   string `json:"type"`
+					Status string `json:"status"`
+				} `json:"conditions"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := getJSON(ctx, clientset, "/apis/cluster.open-cluster-management.io/v1/managedclusters", &clusterList); err != nil {
+		return nil, fmt.Errorf("%s: failed to list managedclusters.cluster.open-cluster-management.io: %w", op, err)
+	}
+
+	// Constructing context configurations
+	var configs []ContextConfig
+	for _, item := range clusterList.Items {
+		clusterName := item.Metadata.Name
+
+		// Skipping clusters that are not available
+		available := false
+		for _, cond := range item.Status.Conditions {
+			if cond.Type == "ManagedClusterConditionAvailable" {
+				available = cond.Status == "True"
+				break
+			}
+		}
+		if !available {
+			scanPrintf(ctx, "\033[33m[%s] Skipped managed cluster %s: not available\033[0m\n", op, clusterName)
+			continue
+		}
+
+		// Rendering sub-cluster server URL
+		var newServer bytes.Buffer
+		if err := tmpl.Execute(&newServer, ocmURLData{
+			Scheme: serverURL.Scheme,
+			Host:   serverURL.Host,
+			Name:   clusterName,
+			Parent: name,
+		}); err != nil {
+			return nil, fmt.Errorf("%s: failed to render URL template for %s: %w", op, clusterName, err)
+		}
+
+		cfg := subContext(parent, fmt.Sprintf("%s-%s", name, clusterName), newServer.String())
+		cfg.SubCluster, cfg.Labels = clusterName, item.Metadata.Labels
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
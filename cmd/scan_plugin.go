@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// pluginPrefix is the executable name prefix of external scanner plugins.
+const pluginPrefix = "kontext-scan-"
+
+// pluginRequest is the JSON document written to a plugin's stdin.
+type pluginRequest struct {
+	Action    string `json:"action"`
+	Name      string `json:"name"`
+	Server    string `json:"server"`
+	Token     string `json:"token"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// Full kubeconfig user entry of the parent, for credentials other than a bearer token
+	User *api.AuthInfo `json:"user,omitempty"`
+}
+
+// pluginEntry is a context printed by a plugin, optionally with its own kubeconfig user entry.
+type pluginEntry struct {
+	ContextConfig
+	User *api.AuthInfo `json:"user,omitempty"`
+}
+
+// pluginScanner runs an external kontext-scan-<type> executable found on $PATH.
+// The plugin receives the parent context as JSON on stdin and prints a JSON list
+// of {"name", "server", "token"} objects on stdout; an entry may carry a full "user" entry
+// instead, and one with neither inherits the parent's credentials and cluster settings.
+type pluginScanner struct {
+	name string
+	path string
+}
+
+// lookupPlugin finds the plugin executable for the given scan type on $PATH.
+func lookupPlugin(clusterType string) (Scanner, bool) {
+	if clusterType == "" || strings.ContainsAny(clusterType, `/\`) {
+		return nil, false
+	}
+	pluginPath, err := exec.LookPath(pluginPrefix + clusterType)
+	if err != nil {
+		return nil, false
+	}
+	return pluginScanner{name: clusterType, path: pluginPath}, true
+}
+
+// pluginTypes returns the scan types provided by plugin executables on $PATH.
+func pluginTypes() []string {
+	var types []string
+	seen := make(map[string]struct{})
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasPrefix(entry.Name(), pluginPrefix) {
+				continue
+			}
+			clusterType := strings.TrimPrefix(entry.Name(), pluginPrefix)
+			if _, exists := seen[clusterType]; exists {
+				continue
+			}
+			if _, ok := lookupPlugin(clusterType); ok {
+				seen[clusterType] = struct{}{}
+				types = append(types, clusterType)
+			}
+		}
+	}
+	return types
+}
+
+func (p pluginScanner) Name() string { return p.name }
+
+// Detect asks the plugin whether it recognizes the parent server.
+// The plugin answers a "detect" action with {"detected": true|false}.
+func (p pluginScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	stdout, err := p.run(ctx, parent.requestTimeout(), pluginRequest{
+		Action: "detect",
+		Name:   parent.Name,
+		Server: parent.Server,
+		Token:  parent.Token,
+		User:   pluginUser(parent),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var resp struct {
+		Detected bool `json:"detected"`
+	}
+	if err := json.Unmarshal(stdout, &resp); err != nil {
+		return false, fmt.Errorf("plugin %s returned malformed detect output: %w", p.path, err)
+	}
+	return resp.Detected, nil
+}
+
+func (p pluginScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanPlugin"
+
+	stdout, err := p.run(ctx, parent.requestTimeout(), pluginRequest{
+		Action:    "scan",
+		Name:      parent.Name,
+		Server:    parent.Server,
+		Token:     parent.Token,
+		Namespace: opts.Namespace,
+		User:      pluginUser(parent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Parsing plugin output
+	var entries []pluginEntry
+	if err := json.Unmarshal(stdout, &entries); err != nil {
+		return nil, fmt.Errorf("%s: plugin %s returned malformed output: %w", op, p.path, err)
+	}
+	var configs []ContextConfig
+	for i, entry := range entries {
+		cfg := entry.ContextConfig
+		if cfg.Name == "" || cfg.Server == "" {
+			return nil, fmt.Errorf("%s: plugin %s returned entry %d without name or server", op, p.path, i)
+		}
+		switch {
+		case entry.User != nil:
+			cfg.Token, cfg.AuthInfo = entry.User.Token, entry.User
+		case cfg.Token == "":
+			// Inheriting the parent's credentials, which may be a client certificate or exec plugin
+			inherited := subContext(parent, cfg.Name, cfg.Server)
+			cfg.Token, cfg.Cluster, cfg.AuthInfo = inherited.Token, inherited.Cluster, inherited.AuthInfo
+		}
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
+
+// pluginUser returns the parent's user entry for a plugin request, without kontext metadata.
+func pluginUser(parent ContextConfig) *api.AuthInfo {
+	if parent.AuthInfo == nil {
+		return nil
+	}
+	user := parent.AuthInfo.DeepCopy()
+	user.Extensions = nil
+	return user
+}
+
+// run executes the plugin with the request on stdin and returns its stdout.
+// The plugin is stopped when ctx is canceled or it runs longer than the timeout.
+func (p pluginScanner) run(ctx context.Context, timeout time.Duration, req pluginRequest) ([]byte, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, p.path)
+	command.Stdin = bytes.NewReader(input)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	if err := command.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("plugin %s timed out after %s", p.path, timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("plugin %s failed: %w: %s", p.path, err, msg)
+		}
+		return nil, fmt.Errorf("plugin %s failed: %w", p.path, err)
+	}
+
+	return stdout.Bytes(), nil
+}
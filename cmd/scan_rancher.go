@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterScanner(rancherScanner{})
+}
+
+// rancherScanner discovers downstream clusters managed by a Rancher server.
+type rancherScanner struct{}
+
+func (rancherScanner) Name() string { return "rancher" }
+
+// Detect checks for the clusters resource in management.cattle.io/v3.
+func (rancherScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+	return hasAPIResource(clientset, "management.cattle.io/v3", "clusters")
+}
+
+func (rancherScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	return ScanRancher(ctx, parent)
+}
+
+// ScanRancher scans for clusters.management.cattle.io resources and creates one context per
+// downstream cluster, reachable through the Rancher proxy at <rancher>/k8s/clusters/<cluster-id>.
+func ScanRancher(ctx context.Context, parent ContextConfig) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanRancher"
+
+	name, server := parent.Name, parent.Server
+
+	// Resolving Rancher base address
+	base, err := serverBase(server)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Checking for clusters resource in management.cattle.io/v3
+	hasClusterResource, err := hasAPIResource(clientset, "management.cattle.io/v3", "clusters")
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to discover management.cattle.io/v3 resources: %w", op, err)
+	}
+	if !hasClusterResource {
+		scanPrintf(ctx, "\033[33m[%s] No clusters.management.cattle.io resources found\033[0m\n", op)
+		return nil, nil
+	}
+
+	// Retrieving cluster resources
+	var clusterList struct {
+		Items []struct {
+			Metadata struct {
+				Name   string            `json:"name"`
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+			Spec struct {
+				DisplayName string `json:"displayName"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := getJSON(ctx, clientset, "/apis/management.cattle.io/v3/clusters", &clusterList); err != nil {
+		return nil, fmt.Errorf("%s: failed to list clusters.management.cattle.io: %w", op, err)
+	}
+
+	// Constructing context configurations
+	var configs []ContextConfig
+	for _, item := range clusterList.Items {
+		clusterID := item.Metadata.Name
+		if strings.ToLower(clusterID) == "local" {
+			continue
+		}
+
+		// Preferring the display name for the context name
+		displayName := item.Spec.DisplayName
+		if displayName == "" {
+			displayName = clusterID
+		}
+
+		cfg := subContext(parent, fmt.Sprintf("%s-%s", name, displayName), fmt.Sprintf("%s/k8s/clusters/%s", base, clusterID))
+		cfg.SubCluster, cfg.Labels = displayName, item.Metadata.Labels
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"text/template"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	RegisterScanner(vclusterScanner{})
+}
+
+// vclusterURLData is the data available to --scan-url-template for vcluster servers.
+type vclusterURLData struct {
+	Name      string // vcluster name
+	Namespace string // host namespace of the vcluster
+	Service   string // name of the vcluster Service
+	Port      int32  // https port of the vcluster Service
+	Parent    string // parent context name
+}
+
+// vclusterScanner discovers virtual clusters running inside a host cluster.
+type vclusterScanner struct{}
+
+func (vclusterScanner) Name() string { return "vcluster" }
+
+// Detect checks for StatefulSets labelled app=vcluster.
+func (vclusterScanner) Detect(ctx context.Context, parent ContextConfig) (bool, error) {
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, parent.requestTimeout())
+	defer cancel()
+
+	statefulSets, err := clientset.AppsV1().StatefulSets("").List(ctx, metav1.ListOptions{
+		LabelSelector: "app=vcluster",
+		Limit:         1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(statefulSets.Items) > 0, nil
+}
+
+func (vclusterScanner) Scan(ctx context.Context, parent ContextConfig, opts ScanOptions) ([]ContextConfig, error) {
+	return ScanVCluster(ctx, parent, opts.URLTemplate)
+}
+
+// ScanVCluster finds vclusters through their app=vcluster StatefulSets and imports the kubeconfig
+// stored in each vc-<name> Secret. Servers pointing at localhost are rewritten to the vcluster
+// Service in the host cluster, or to urlTemplate when it is set.
+func ScanVCluster(ctx context.Context, parent ContextConfig, urlTemplate string) ([]ContextConfig, error) {
+	const op = "kubeconfig.ScanVCluster"
+
+	name, server := parent.Name, parent.Server
+
+	// Compiling the server URL template
+	var tmpl *template.Template
+	if urlTemplate != "" {
+		var err error
+		tmpl, err = template.New("vcluster").Option("missingkey=error").Parse(urlTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid URL template: %w", op, err)
+		}
+	}
+
+	// Creating Kubernetes client
+	clientset, err := newScanClient(parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create Kubernetes client for %s: %w", op, server, err)
+	}
+
+	// Retrieving vcluster StatefulSets across namespaces
+	ctx, cancel := context.WithTimeout(ctx, parent.requestTimeout())
+	defer cancel()
+
+	statefulSets, err := clientset.AppsV1().StatefulSets("").List(ctx, metav1.ListOptions{
+		LabelSelector: "app=vcluster",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list vcluster statefulsets: %w", op, err)
+	}
+	if len(statefulSets.Items) == 0 {
+		scanPrintf(ctx, "\033[33m[%s] No vcluster statefulsets found\033[0m\n", op)
+		return nil, nil
+	}
+
+	// Constructing context configurations
+	var configs []ContextConfig
+	for _, sts := range statefulSets.Items {
+		namespace := sts.Namespace
+		vclusterName := sts.Labels["release"]
+		if vclusterName == "" {
+			vclusterName = sts.Name
+		}
+
+		// Importing the vcluster kubeconfig
+		secretName := "vc-" + vclusterName
+		data, err := getSecretData(ctx, clientset, namespace, secretName, "config")
+		if err != nil {
+			scanPrintf(ctx, "\033[33m[%s] Skipped vcluster %s/%s: %v\033[0m\n", op, namespace, vclusterName, err)
+			continue
+		}
+		cfg, err := contextFromKubeconfig(fmt.Sprintf("%s-%s-%s", name, namespace, vclusterName), data)
+		if err != nil {
+			scanPrintf(ctx, "\033[33m[%s] Skipped vcluster %s/%s: %v\033[0m\n", op, namespace, vclusterName, err)
+			continue
+		}
+
+		// Rewriting localhost servers to a reachable address
+		if isLocalServer(cfg.Server) {
+			service, err := clientset.CoreV1().Services(namespace).Get(ctx, vclusterName, metav1.GetOptions{})
+			if err != nil {
+				scanPrintf(ctx, "\033[33m[%s] Skipped vcluster %s/%s: failed to get service: %v\033[0m\n", op, namespace, vclusterName, err)
+				continue
+			}
+			port := int32(443)
+			for _, p := range service.Spec.Ports {
+				if p.Name == "https" {
+					port = p.Port
+					break
+				}
+			}
+
+			newServer := fmt.Sprintf("https://%s.%s.svc:%d", service.Name, namespace, port)
+			if tmpl != nil {
+				var buf bytes.Buffer
+				if err := tmpl.Execute(&buf, vclusterURLData{
+					Name:      vclusterName,
+					Namespace: namespace,
+					Service:   service.Name,
+					Port:      port,
+					Parent:    name,
+				}); err != nil {
+					return nil, fmt.Errorf("%s: failed to render URL template for %s/%s: %w", op, namespace, vclusterName, err)
+				}
+				newServer = buf.String()
+			}
+
+			// Keeping certificate verification against the localhost name the certificate was issued for
+			if !cfg.Cluster.InsecureSkipTLSVerify && cfg.Cluster.TLSServerName == "" {
+				if u, err := url.Parse(cfg.Server); err == nil {
+					cfg.Cluster.TLSServerName = u.Hostname()
+				}
+			}
+			cfg.Server = newServer
+			cfg.Cluster.Server = newServer
+		}
+
+		cfg.SubCluster, cfg.Labels = vclusterName, sts.Labels
+		configs = append(configs, cfg)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return configs, nil
+}
+
+// isLocalServer reports whether a server address points at the local host.
+func isLocalServer(server string) bool {
+	u, err := url.Parse(server)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
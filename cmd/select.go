@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SelectContexts shows discovered contexts as a checklist and reads the selection from in.
+// All contexts start selected; each input line toggles numbers or ranges (e.g., 1,3-5),
+// or selects all or none, until an empty line accepts the current selection.
+func SelectContexts(in io.Reader, configs []ContextConfig) ([]ContextConfig, error) {
+	const op = "kubeconfig.SelectContexts"
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	selected := make([]bool, len(configs))
+	for i := range selected {
+		selected[i] = true
+	}
+
+	reader := bufio.NewReader(in)
+	for {
+		// Displaying the checklist
+		fmt.Printf("\033[36m[%s] Discovered sub-clusters:\033[0m\n", op)
+		for i, cfg := range configs {
+			mark := " "
+			if selected[i] {
+				mark = "x"
+			}
+			fmt.Printf("  [%s] %d. %s (%s)\n", mark, i+1, cfg.Name, cfg.Server)
+			if cfg.Warning != "" {
+				fmt.Printf("\033[33m         ⚠ %s\033[0m\n", cfg.Warning)
+			}
+		}
+		fmt.Printf("Toggle numbers or ranges (e.g., 1,3-5), 'all' or 'none'; press Enter to continue: ")
+
+		// Reading the next toggle
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("%s: failed to read selection: %w", op, err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if err == io.EOF {
+				fmt.Println()
+			}
+			break
+		}
+
+		switch strings.ToLower(line) {
+		case "all", "none":
+			for i := range selected {
+				selected[i] = strings.EqualFold(line, "all")
+			}
+		default:
+			indexes, perr := parseSelection(line, len(configs))
+			if perr != nil {
+				fmt.Printf("\033[31m  ✗ %v\033[0m\n", perr)
+				break
+			}
+			for _, i := range indexes {
+				selected[i] = !selected[i]
+			}
+		}
+		if err == io.EOF {
+			fmt.Println()
+			break
+		}
+	}
+
+	var chosen []ContextConfig
+	for i, cfg := range configs {
+		if selected[i] {
+			chosen = append(chosen, cfg)
+		}
+	}
+	return chosen, nil
+}
+
+// parseSelection parses comma-separated 1-based numbers and ranges into 0-based indexes below n.
+func parseSelection(input string, n int) ([]int, error) {
+	var indexes []int
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		low, high := part, part
+		if l, h, ok := strings.Cut(part, "-"); ok {
+			low, high = strings.TrimSpace(l), strings.TrimSpace(h)
+		}
+		start, err := strconv.Atoi(low)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		end, err := strconv.Atoi(high)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		if start < 1 || end > n || start > end {
+			return nil, fmt.Errorf("selection %q is out of range 1-%d", part, n)
+		}
+		for i := start; i <= end; i++ {
+			indexes = append(indexes, i-1)
+		}
+	}
+	return indexes, nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"kontext/cmd"
 
@@ -15,17 +16,31 @@ var (
 	token  string
 	path   string
 	scan   string
-)
 
-// Add an empty string to allow omitting the scan parameter
-var validScans = []string{"alauda", ""}
+	scanNamespace   string
+	scanURLTemplate string
+	scanExpiration  time.Duration
+	scanDepth       int
+	scanTimeout     time.Duration
+	parallel        int
+	scanInclude     []string
+	scanExclude     []string
+	interactive     bool
+	skipUnhealthy   bool
+	scanDirect      bool
+	quarantine      bool
+	cascade         bool
+	insecure        bool
+	onConflict      string
+	nameTemplate    string
+)
 
 func main() {
 	var rootCmd = &cobra.Command{
 		Use:   "kontext",
 		Short: "Manage Kubernetes contexts efficiently",
 		Long: `Kontext is a CLI tool for managing Kubernetes contexts in your kubectl configuration.
-It provides commands to add, list, merge, delete, and clean Kubernetes contexts.`,
+It provides commands to add, list, merge, delete, clean, and refresh Kubernetes contexts.`,
 	}
 
 	var addCmd = &cobra.Command{
@@ -52,7 +67,11 @@ It provides commands to add, list, merge, delete, and clean Kubernetes contexts.
 			if scan != "" {
 				scanPtr = &scan
 			}
-			if err := cmd.AddContext(name, server, token, scanPtr); err != nil {
+			opts, err := scanOptions()
+			if err != nil {
+				return fmt.Errorf("invalid scan options: %w", err)
+			}
+			if err := cmd.AddContext(name, server, token, scanPtr, opts); err != nil {
 				return fmt.Errorf("failed to add context: %w", err)
 			}
 			return nil
@@ -88,11 +107,18 @@ It provides commands to add, list, merge, delete, and clean Kubernetes contexts.
 			if err := validateScan(scan); err != nil {
 				return fmt.Errorf("invalid scan value: %w", err)
 			}
+			if !cmd.ValidConflictStrategy(onConflict) {
+				return fmt.Errorf("on-conflict must be one of: %v", cmd.ConflictStrategies)
+			}
 			var scanPtr *string
 			if scan != "" {
 				scanPtr = &scan
 			}
-			if err := cmd.MergeContext(path, name, scanPtr); err != nil {
+			opts, err := scanOptions()
+			if err != nil {
+				return fmt.Errorf("invalid scan options: %w", err)
+			}
+			if err := cmd.MergeContext(path, name, insecure, onConflict, scanPtr, opts); err != nil {
 				return fmt.Errorf("failed to merge kubeconfig: %w", err)
 			}
 			return nil
@@ -115,48 +141,104 @@ It provides commands to add, list, merge, delete, and clean Kubernetes contexts.
 	}
 
 	var deleteCmd = &cobra.Command{
-		Use:   "delete",
+		Use:   "delete [name]",
 		Short: "Delete Kubernetes contexts",
-		Long:  `Deletes one or more Kubernetes contexts from the kubectl configuration, supporting wildcard name patterns (e.g., name*).`,
+		Long:  `Deletes one or more Kubernetes contexts from the kubectl configuration, supporting wildcard name patterns (e.g., name*). With --cascade, contexts discovered through the deleted contexts are deleted as well.`,
 		RunE: func(c *cobra.Command, args []string) error {
-			if len(args) > 0 {
-				return fmt.Errorf("delete command does not accept arguments, received: %v", args)
+			if len(args) > 1 || (len(args) == 1 && name != "") {
+				return fmt.Errorf("delete command accepts the context name either as --name or as a single argument, received: %v", args)
+			}
+			if len(args) == 1 {
+				name = args[0]
 			}
 			if err := validateName(name); err != nil {
 				return fmt.Errorf("invalid name: %w", err)
 			}
-			if err := cmd.DeleteContext(name); err != nil {
+			if err := cmd.DeleteContext(name, cascade); err != nil {
 				return fmt.Errorf("failed to delete context %q: %w", name, err)
 			}
 			return nil
 		},
 	}
 
+	var refreshCmd = &cobra.Command{
+		Use:   "refresh <context>",
+		Short: "Re-scan a platform context",
+		Long:  `Re-runs the scan a context was added with, adding contexts for new sub-clusters and removing (or quarantining) contexts whose sub-cluster is gone.`,
+		RunE: func(c *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("refresh command requires exactly one context name, received: %v", args)
+			}
+			if err := validateName(args[0]); err != nil {
+				return fmt.Errorf("invalid name: %w", err)
+			}
+			opts, err := scanOptions()
+			if err != nil {
+				return fmt.Errorf("invalid scan options: %w", err)
+			}
+			if err := cmd.RefreshPlatform(args[0], quarantine, opts.Timeout); err != nil {
+				return fmt.Errorf("failed to refresh context %q: %w", args[0], err)
+			}
+			return nil
+		},
+	}
+
 	// Flag definitions
 	addCmd.Flags().StringVar(&name, "name", "", "Name for the context, cluster, and user (required)")
 	addCmd.Flags().StringVar(&server, "server", "", "Kubernetes API server address (required)")
 	addCmd.Flags().StringVar(&token, "token", "", "Kubernetes authentication token (required)")
-	addCmd.Flags().StringVar(&scan, "scan", "", "Cluster type to scan for sub-clusters (e.g., alauda)")
+	addCmd.Flags().StringVar(&scan, "scan", "", "Cluster type to scan for sub-clusters (e.g., alauda, or auto to detect)")
+	addCmd.Flags().StringVar(&scanNamespace, "scan-namespace", "", "Namespace to scan, for scanners that read a single namespace (e.g., argocd)")
+	addCmd.Flags().DurationVar(&scanExpiration, "scan-expiration", 0, "Lifetime of credentials issued by scanners (e.g., gardener, default 24h)")
+	addCmd.Flags().DurationVar(&scanTimeout, "scan-timeout", 30*time.Second, "Time limit of each platform detection and scan, including its API requests")
+	addCmd.Flags().IntVar(&scanDepth, "scan-depth", 1, "Number of levels to scan; discovered contexts are scanned again with auto detection")
+	addCmd.Flags().StringVar(&scanURLTemplate, "scan-url-template", "", "Go template for sub-cluster server URLs (e.g., vcluster: https://{{.Name}}.example.com)")
+	addCmd.Flags().StringArrayVar(&scanInclude, "scan-include", nil, "Only add sub-clusters matching this name or key=value label pattern (glob, or re:<regexp>; repeatable)")
+	addCmd.Flags().StringArrayVar(&scanExclude, "scan-exclude", nil, "Skip sub-clusters matching this name or key=value label pattern (glob, or re:<regexp>; repeatable)")
+	addCmd.Flags().BoolVar(&interactive, "interactive", false, "Choose which discovered sub-clusters to add from a checklist")
+	addCmd.Flags().StringVar(&nameTemplate, "name-template", "", "Go template for the names of discovered contexts (e.g., {{.Parent}}.{{.SubCluster}}); defaults to nameTemplate in the kontext config file")
+	addCmd.Flags().BoolVar(&scanDirect, "scan-direct", false, "Connect to sub-clusters through their own API server address instead of the platform proxy (e.g., alauda)")
+	addCmd.Flags().BoolVar(&skipUnhealthy, "scan-skip-unhealthy", false, "Skip sub-clusters reported as unhealthy (e.g., not Running) instead of adding them with a warning")
 	addCmd.MarkFlagRequired("name")
 	addCmd.MarkFlagRequired("server")
 	addCmd.MarkFlagRequired("token")
 
-	addCmd.RegisterFlagCompletionFunc("scan", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return validScans, cobra.ShellCompDirectiveNoFileComp
+	addCmd.RegisterFlagCompletionFunc("scan", func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return append(cmd.ScanTypes(), cmd.AutoScan), cobra.ShellCompDirectiveNoFileComp
 	})
 
 	mergeCmd.Flags().StringVar(&name, "name", "", "Optional name prefix for the context, cluster, and user")
 	mergeCmd.Flags().StringVar(&path, "path", "", "Path to the kubeconfig file (required)")
-	mergeCmd.Flags().StringVar(&scan, "scan", "", "Cluster type to scan for sub-clusters (e.g., alauda)")
+	mergeCmd.Flags().StringVar(&onConflict, "on-conflict", cmd.ConflictFail, "How to handle contexts whose name already exists: fail, skip, overwrite or rename")
+	mergeCmd.Flags().BoolVar(&insecure, "insecure", false, "Skip TLS verification of merged clusters instead of keeping their certificate authority")
+	mergeCmd.Flags().StringVar(&scan, "scan", "", "Cluster type to scan for sub-clusters (e.g., alauda, or auto to detect)")
+	mergeCmd.Flags().StringVar(&scanNamespace, "scan-namespace", "", "Namespace to scan, for scanners that read a single namespace (e.g., argocd)")
+	mergeCmd.Flags().DurationVar(&scanExpiration, "scan-expiration", 0, "Lifetime of credentials issued by scanners (e.g., gardener, default 24h)")
+	mergeCmd.Flags().DurationVar(&scanTimeout, "scan-timeout", 30*time.Second, "Time limit of each platform detection and scan, including its API requests")
+	mergeCmd.Flags().IntVar(&parallel, "parallel", 1, "Number of contexts scanned concurrently")
+	mergeCmd.Flags().IntVar(&scanDepth, "scan-depth", 1, "Number of levels to scan; discovered contexts are scanned again with auto detection")
+	mergeCmd.Flags().StringVar(&scanURLTemplate, "scan-url-template", "", "Go template for sub-cluster server URLs (e.g., vcluster: https://{{.Name}}.example.com)")
+	mergeCmd.Flags().StringArrayVar(&scanInclude, "scan-include", nil, "Only add sub-clusters matching this name or key=value label pattern (glob, or re:<regexp>; repeatable)")
+	mergeCmd.Flags().StringArrayVar(&scanExclude, "scan-exclude", nil, "Skip sub-clusters matching this name or key=value label pattern (glob, or re:<regexp>; repeatable)")
+	mergeCmd.Flags().BoolVar(&interactive, "interactive", false, "Choose which discovered sub-clusters to add from a checklist")
+	mergeCmd.Flags().StringVar(&nameTemplate, "name-template", "", "Go template for the names of merged and discovered contexts (e.g., {{if .SubCluster}}{{.Parent}}.{{.SubCluster}}{{else}}{{.File}}-{{.Context}}{{end}}); defaults to nameTemplate in the kontext config file")
+	mergeCmd.Flags().BoolVar(&scanDirect, "scan-direct", false, "Connect to sub-clusters through their own API server address instead of the platform proxy (e.g., alauda)")
+	mergeCmd.Flags().BoolVar(&skipUnhealthy, "scan-skip-unhealthy", false, "Skip sub-clusters reported as unhealthy (e.g., not Running) instead of adding them with a warning")
 	mergeCmd.MarkFlagRequired("path")
-	mergeCmd.RegisterFlagCompletionFunc("scan", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return validScans, cobra.ShellCompDirectiveNoFileComp
+	mergeCmd.RegisterFlagCompletionFunc("on-conflict", func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmd.ConflictStrategies, cobra.ShellCompDirectiveNoFileComp
 	})
+	mergeCmd.RegisterFlagCompletionFunc("scan", func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return append(cmd.ScanTypes(), cmd.AutoScan), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	deleteCmd.Flags().StringVar(&name, "name", "", "Name of the context to delete (supports wildcard patterns)")
+	deleteCmd.Flags().BoolVar(&cascade, "cascade", false, "Also delete contexts discovered through the deleted contexts")
 
-	deleteCmd.Flags().StringVar(&name, "name", "", "Name of the context to delete (supports wildcard patterns, required)")
-	deleteCmd.MarkFlagRequired("name")
+	refreshCmd.Flags().BoolVar(&quarantine, "quarantine", false, "Mark contexts whose sub-cluster is gone as quarantined instead of removing them")
+	refreshCmd.Flags().DurationVar(&scanTimeout, "scan-timeout", 30*time.Second, "Time limit of each platform detection and scan, including its API requests")
 
-	rootCmd.AddCommand(addCmd, mergeCmd, deleteCmd, cleanCmd, listCmd)
+	rootCmd.AddCommand(addCmd, mergeCmd, deleteCmd, cleanCmd, listCmd, refreshCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -183,12 +265,57 @@ func validateServer(server string) error {
 	return nil
 }
 
-// validateScan ensures the scan type in validScans
-func validateScan(scan string) error {
-	for _, valid := range validScans {
-		if scan == valid {
-			return nil
+// scanOptions collects and validates the scanner settings from the command-line flags
+func scanOptions() (cmd.ScanOptions, error) {
+	if scanTimeout <= 0 {
+		return cmd.ScanOptions{}, fmt.Errorf("scan timeout must be positive, got %s", scanTimeout)
+	}
+	if parallel < 1 {
+		return cmd.ScanOptions{}, fmt.Errorf("parallel must be at least 1, got %d", parallel)
+	}
+	if scanDepth < 1 {
+		return cmd.ScanOptions{}, fmt.Errorf("scan depth must be at least 1, got %d", scanDepth)
+	}
+	opts := cmd.ScanOptions{
+		Namespace:     scanNamespace,
+		URLTemplate:   scanURLTemplate,
+		Expiration:    scanExpiration,
+		Depth:         scanDepth,
+		Timeout:       scanTimeout,
+		Parallel:      parallel,
+		Interactive:   interactive,
+		SkipUnhealthy: skipUnhealthy,
+		Direct:        scanDirect,
+	}
+	names, err := cmd.LoadNameTemplate(nameTemplate)
+	if err != nil {
+		return opts, err
+	}
+	opts.NameTemplate = names
+	for _, pattern := range scanInclude {
+		f, err := cmd.ParseScanFilter(pattern)
+		if err != nil {
+			return opts, err
+		}
+		opts.Include = append(opts.Include, f)
+	}
+	for _, pattern := range scanExclude {
+		f, err := cmd.ParseScanFilter(pattern)
+		if err != nil {
+			return opts, err
 		}
+		opts.Exclude = append(opts.Exclude, f)
+	}
+	return opts, nil
+}
+
+// validateScan ensures the scan type is registered, allowing an empty value to omit scanning
+func validateScan(scan string) error {
+	if scan == "" || scan == cmd.AutoScan {
+		return nil
+	}
+	if _, ok := cmd.GetScanner(scan); ok {
+		return nil
 	}
-	return fmt.Errorf("scan must be one of: %v", validScans)
+	return fmt.Errorf("scan must be one of: %v", append(cmd.ScanTypes(), cmd.AutoScan))
 }